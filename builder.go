@@ -0,0 +1,429 @@
+package typeform
+
+// FormBuilder builds a Form one field at a time; each field type
+// accepts only the options that apply to it
+//
+//	form, err := typeform.NewForm("Survey").
+//		ShortText("Your initials?", typeform.MaxChars(3)).
+//		YesNo("Do you like forms?", typeform.Ref("likes")).
+//		Rating("How much?", typeform.Ref("how-much"), typeform.Steps(5)).
+//		JumpIf("likes", true, "how-much").
+//		Build()
+type FormBuilder struct {
+	form Form
+}
+
+// NewForm starts building a form with the provided title
+func NewForm(title string) *FormBuilder {
+	return &FormBuilder{
+		form: Form{
+			Title: title,
+		},
+	}
+}
+
+// Build validates the form with Form.Validate and returns it
+func (builder *FormBuilder) Build() (Form, error) {
+	form := builder.form
+	err := form.Validate()
+	if err != nil {
+		return Form{}, err
+	}
+	return form, nil
+}
+
+// Tags sets the tags of the form
+func (builder *FormBuilder) Tags(tags ...string) *FormBuilder {
+	builder.form.Tags = tags
+	return builder
+}
+
+// DesignID sets the ID of the design of the form
+func (builder *FormBuilder) DesignID(designID string) *FormBuilder {
+	builder.form.DesignID = designID
+	return builder
+}
+
+// WebhookSubmitURL sets where the responses go to when a respondent submits the form
+func (builder *FormBuilder) WebhookSubmitURL(webhookSubmitURL string) *FormBuilder {
+	builder.form.WebhookSubmitURL = webhookSubmitURL
+	return builder
+}
+
+// URLIDs sets the IDs of the URLs the form is displayed at
+func (builder *FormBuilder) URLIDs(urlIDs ...string) *FormBuilder {
+	builder.form.URLIDs = urlIDs
+	return builder
+}
+
+// Branding enables or disables the Typeform branding for the form
+func (builder *FormBuilder) Branding(branding bool) *FormBuilder {
+	builder.form.Branding = branding
+	return builder
+}
+
+// JumpIf adds a logic jump from the field with Ref from to the field with Ref to,
+// taken when the answer to the first field is answer
+func (builder *FormBuilder) JumpIf(from string, answer bool, to string) *FormBuilder {
+	builder.form.LogicJumps = append(builder.form.LogicJumps, LogicJump{
+		From: from,
+		To:   to,
+		If:   answer,
+	})
+	return builder
+}
+
+func (builder *FormBuilder) addField(fieldType FieldType, question string, apply func(field *Field)) *FormBuilder {
+	field := Field{
+		Type:     fieldType,
+		Question: question,
+	}
+	apply(&field)
+	builder.form.Fields = append(builder.form.Fields, field)
+	return builder
+}
+
+func choicesFromLabels(labels []string) []Choice {
+	choices := make([]Choice, len(labels))
+	for i, label := range labels {
+		choices[i].Label = label
+	}
+	return choices
+}
+
+// ShortText adds a short_text field
+func (builder *FormBuilder) ShortText(question string, options ...TextOption) *FormBuilder {
+	return builder.addField(ShortText, question, func(field *Field) {
+		for _, option := range options {
+			option.applyText(field)
+		}
+	})
+}
+
+// LongText adds a long_text field
+func (builder *FormBuilder) LongText(question string, options ...TextOption) *FormBuilder {
+	return builder.addField(LongText, question, func(field *Field) {
+		for _, option := range options {
+			option.applyText(field)
+		}
+	})
+}
+
+// MultipleChoice adds a multiple_choice field with a choice for each label
+func (builder *FormBuilder) MultipleChoice(question string, labels []string, options ...ChoiceOption) *FormBuilder {
+	return builder.addField(MultipleChoice, question, func(field *Field) {
+		field.Choices = choicesFromLabels(labels)
+		for _, option := range options {
+			option.applyChoice(field)
+		}
+	})
+}
+
+// PictureChoice adds a picture_choice field
+func (builder *FormBuilder) PictureChoice(question string, choices []Choice, options ...PictureChoiceOption) *FormBuilder {
+	return builder.addField(PictureChoice, question, func(field *Field) {
+		field.Choices = choices
+		for _, option := range options {
+			option.applyPictureChoice(field)
+		}
+	})
+}
+
+// Statement adds a statement field
+func (builder *FormBuilder) Statement(text string, options ...StatementOption) *FormBuilder {
+	return builder.addField(Statement, text, func(field *Field) {
+		for _, option := range options {
+			option.applyStatement(field)
+		}
+	})
+}
+
+// Dropdown adds a dropdown field with a choice for each label
+func (builder *FormBuilder) Dropdown(question string, labels []string, options ...DropdownOption) *FormBuilder {
+	return builder.addField(Dropdown, question, func(field *Field) {
+		field.Choices = choicesFromLabels(labels)
+		for _, option := range options {
+			option.applyDropdown(field)
+		}
+	})
+}
+
+// YesNo adds a yes_no field
+func (builder *FormBuilder) YesNo(question string, options ...FieldOption) *FormBuilder {
+	return builder.addPlainField(YesNo, question, options)
+}
+
+// Number adds a number field
+func (builder *FormBuilder) Number(question string, options ...NumberOption) *FormBuilder {
+	return builder.addField(Number, question, func(field *Field) {
+		for _, option := range options {
+			option.applyNumber(field)
+		}
+	})
+}
+
+// Rating adds a rating field
+func (builder *FormBuilder) Rating(question string, options ...RatingOption) *FormBuilder {
+	return builder.addField(Rating, question, func(field *Field) {
+		for _, option := range options {
+			option.applyRating(field)
+		}
+	})
+}
+
+// OpinionScale adds an opinion_scale field; without the Steps option, the API uses its default of 11 steps
+func (builder *FormBuilder) OpinionScale(question string, options ...OpinionScaleOption) *FormBuilder {
+	return builder.addField(OpinionScale, question, func(field *Field) {
+		for _, option := range options {
+			option.applyOpinionScale(field)
+		}
+	})
+}
+
+// Email adds an email field
+func (builder *FormBuilder) Email(question string, options ...FieldOption) *FormBuilder {
+	return builder.addPlainField(Email, question, options)
+}
+
+// Website adds a website field
+func (builder *FormBuilder) Website(question string, options ...FieldOption) *FormBuilder {
+	return builder.addPlainField(Website, question, options)
+}
+
+// Legal adds a legal field
+func (builder *FormBuilder) Legal(question string, options ...FieldOption) *FormBuilder {
+	return builder.addPlainField(Legal, question, options)
+}
+
+func (builder *FormBuilder) addPlainField(fieldType FieldType, question string, options []FieldOption) *FormBuilder {
+	return builder.addField(fieldType, question, func(field *Field) {
+		for _, option := range options {
+			option.applyField(field)
+		}
+	})
+}
+
+// TextOption is an option of short_text and long_text fields
+type TextOption interface {
+	applyText(field *Field)
+}
+
+// ChoiceOption is an option of multiple_choice fields
+type ChoiceOption interface {
+	applyChoice(field *Field)
+}
+
+// PictureChoiceOption is an option of picture_choice fields
+type PictureChoiceOption interface {
+	applyPictureChoice(field *Field)
+}
+
+// StatementOption is an option of statement fields
+type StatementOption interface {
+	applyStatement(field *Field)
+}
+
+// DropdownOption is an option of dropdown fields
+type DropdownOption interface {
+	applyDropdown(field *Field)
+}
+
+// NumberOption is an option of number fields
+type NumberOption interface {
+	applyNumber(field *Field)
+}
+
+// RatingOption is an option of rating fields
+type RatingOption interface {
+	applyRating(field *Field)
+}
+
+// OpinionScaleOption is an option of opinion_scale fields
+type OpinionScaleOption interface {
+	applyOpinionScale(field *Field)
+}
+
+// FieldOption is an option that applies to fields of any type
+type FieldOption interface {
+	TextOption
+	ChoiceOption
+	PictureChoiceOption
+	StatementOption
+	DropdownOption
+	NumberOption
+	RatingOption
+	OpinionScaleOption
+	applyField(field *Field)
+}
+
+// SelectionOption is an option of multiple_choice and picture_choice fields
+type SelectionOption interface {
+	ChoiceOption
+	PictureChoiceOption
+}
+
+// StepsOption is an option of rating and opinion_scale fields
+type StepsOption interface {
+	RatingOption
+	OpinionScaleOption
+}
+
+type commonOption func(field *Field)
+
+func (option commonOption) applyField(field *Field)         { option(field) }
+func (option commonOption) applyText(field *Field)          { option(field) }
+func (option commonOption) applyChoice(field *Field)        { option(field) }
+func (option commonOption) applyPictureChoice(field *Field) { option(field) }
+func (option commonOption) applyStatement(field *Field)     { option(field) }
+func (option commonOption) applyDropdown(field *Field)      { option(field) }
+func (option commonOption) applyNumber(field *Field)        { option(field) }
+func (option commonOption) applyRating(field *Field)        { option(field) }
+func (option commonOption) applyOpinionScale(field *Field)  { option(field) }
+
+type selectionOption func(field *Field)
+
+func (option selectionOption) applyChoice(field *Field)        { option(field) }
+func (option selectionOption) applyPictureChoice(field *Field) { option(field) }
+
+type stepsOption func(field *Field)
+
+func (option stepsOption) applyRating(field *Field)       { option(field) }
+func (option stepsOption) applyOpinionScale(field *Field) { option(field) }
+
+type textOption func(field *Field)
+
+func (option textOption) applyText(field *Field) { option(field) }
+
+type choiceOption func(field *Field)
+
+func (option choiceOption) applyChoice(field *Field) { option(field) }
+
+type pictureChoiceOption func(field *Field)
+
+func (option pictureChoiceOption) applyPictureChoice(field *Field) { option(field) }
+
+type statementOption func(field *Field)
+
+func (option statementOption) applyStatement(field *Field) { option(field) }
+
+type dropdownOption func(field *Field)
+
+func (option dropdownOption) applyDropdown(field *Field) { option(field) }
+
+type numberOption func(field *Field)
+
+func (option numberOption) applyNumber(field *Field) { option(field) }
+
+type ratingOption func(field *Field)
+
+func (option ratingOption) applyRating(field *Field) { option(field) }
+
+type opinionScaleOption func(field *Field)
+
+func (option opinionScaleOption) applyOpinionScale(field *Field) { option(field) }
+
+// Ref sets the unique reference of the field, used by logic jumps
+func Ref(ref string) FieldOption {
+	return commonOption(func(field *Field) { field.Ref = ref })
+}
+
+// Required makes the field mandatory
+func Required() FieldOption {
+	return commonOption(func(field *Field) { field.Required = true })
+}
+
+// Description sets the sub-text that appears below the question
+func Description(description string) FieldOption {
+	return commonOption(func(field *Field) { field.Description = description })
+}
+
+// Tags sets the tags of the field
+func Tags(tags ...string) FieldOption {
+	return commonOption(func(field *Field) { field.Tags = tags })
+}
+
+// MaxChars sets the maximum number of characters of the answer
+func MaxChars(maxCharacters int) TextOption {
+	return textOption(func(field *Field) { field.MaxCharacters = maxCharacters })
+}
+
+// AllowMultipleSelections lets the respondent choose more than one choice
+func AllowMultipleSelections() SelectionOption {
+	return selectionOption(func(field *Field) { field.AllowMultipleSelections = true })
+}
+
+// Randomize randomizes the order of the choices on every load
+func Randomize() SelectionOption {
+	return selectionOption(func(field *Field) { field.Randomize = true })
+}
+
+// AddOtherChoice adds an "Other" choice which transforms into an open ended text field
+func AddOtherChoice() SelectionOption {
+	return selectionOption(func(field *Field) { field.AddOtherChoice = true })
+}
+
+// VerticalAlignment shows one choice per row
+func VerticalAlignment() ChoiceOption {
+	return choiceOption(func(field *Field) { field.VerticalAlignment = true })
+}
+
+// ShowLabels shows the labels beneath the pictures
+func ShowLabels() PictureChoiceOption {
+	return pictureChoiceOption(func(field *Field) { field.ShowLabels = true })
+}
+
+// Supersize makes the pictures large
+func Supersize() PictureChoiceOption {
+	return pictureChoiceOption(func(field *Field) { field.Supersize = true })
+}
+
+// ButtonText sets the text of the button that jumps to the next field
+func ButtonText(buttonText string) StatementOption {
+	return statementOption(func(field *Field) { field.ButtonText = buttonText })
+}
+
+// HideMarks removes the quotation marks around the statement
+func HideMarks() StatementOption {
+	return statementOption(func(field *Field) { field.HideMarks = true })
+}
+
+// AlphabeticalOrder sorts the choices in alphabetical order
+func AlphabeticalOrder() DropdownOption {
+	return dropdownOption(func(field *Field) { field.AlphabeticalOrder = true })
+}
+
+// MinValue sets the minimum value of the answer
+func MinValue(minValue int) NumberOption {
+	return numberOption(func(field *Field) { field.MinValue, field.minValueSet = minValue, minValue == 0 })
+}
+
+// MaxValue sets the maximum value of the answer
+func MaxValue(maxValue int) NumberOption {
+	return numberOption(func(field *Field) { field.MaxValue, field.maxValueSet = maxValue, maxValue == 0 })
+}
+
+// Steps sets the number of steps of a rating (1 to 10) or of an opinion scale (5 to 11)
+func Steps(steps int) StepsOption {
+	return stepsOption(func(field *Field) { field.Steps = steps })
+}
+
+// Shape sets the icon of the steps of a rating, e.g. "star" or "heart"
+func Shape(shape string) RatingOption {
+	return ratingOption(func(field *Field) { field.Shape = shape })
+}
+
+// ScaleLabels sets the left, center and right labels of an opinion scale
+func ScaleLabels(left, center, right string) OpinionScaleOption {
+	return opinionScaleOption(func(field *Field) {
+		field.Labels = &Labels{
+			Left:   left,
+			Center: center,
+			Right:  right,
+		}
+	})
+}
+
+// StartAtOne makes an opinion scale start at one instead of zero
+func StartAtOne() OpinionScaleOption {
+	return opinionScaleOption(func(field *Field) { field.StartAtOne = true })
+}
@@ -0,0 +1,47 @@
+package typeform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormBuilder(t *testing.T) {
+	form, err := NewForm("Survey").
+		Tags("survey").
+		Branding(true).
+		ShortText("Your initials?", MaxChars(3), Required()).
+		MultipleChoice("Pick", []string{"this", "that"}, AllowMultipleSelections(), VerticalAlignment()).
+		YesNo("Do you like forms?", Ref("likes")).
+		Statement("Thanks", ButtonText("Ok")).
+		Rating("How much?", Ref("how-much"), Steps(5), Shape("heart")).
+		OpinionScale("Scale", Steps(11), ScaleLabels("bad", "meh", "good"), StartAtOne()).
+		JumpIf("likes", true, "how-much").
+		Build()
+	assert.Nil(t, err, "no error should occur")
+
+	assert.Equal(t, Form{
+		Title:    "Survey",
+		Tags:     []string{"survey"},
+		Branding: true,
+		Fields: []Field{
+			{Type: ShortText, Question: "Your initials?", MaxCharacters: 3, Required: true},
+			{Type: MultipleChoice, Question: "Pick", Choices: []Choice{{Label: "this"}, {Label: "that"}}, AllowMultipleSelections: true, VerticalAlignment: true},
+			{Type: YesNo, Question: "Do you like forms?", Ref: "likes"},
+			{Type: Statement, Question: "Thanks", ButtonText: "Ok"},
+			{Type: Rating, Question: "How much?", Ref: "how-much", Steps: 5, Shape: "heart"},
+			{Type: OpinionScale, Question: "Scale", Steps: 11, Labels: &Labels{Left: "bad", Center: "meh", Right: "good"}, StartAtOne: true},
+		},
+		LogicJumps: []LogicJump{{From: "likes", To: "how-much", If: true}},
+	}, form)
+}
+
+func TestFormBuilderValidates(t *testing.T) {
+	_, err := NewForm("Broken").
+		Dropdown("Choose", nil).
+		YesNo("Jump?", Ref("jump")).
+		JumpIf("jump", true, "nowhere").
+		Build()
+	assert.NotNil(t, err, "Build should validate the form")
+	assert.Len(t, err.(ValidationErrors), 1, "logic issues are not API violations")
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+)
+
+// commands are the commands of tfctl, mirroring the methods of typeform.Client
+var commands = []*command{
+	{
+		name:        "info",
+		description: "Get info about the API",
+		run:         runInfo,
+	},
+	{
+		name: "forms",
+		subcommands: []*command{
+			{
+				name:        "create",
+				usage:       "-f FILE",
+				description: "Create a form from a YAML or JSON definition file; its design is created too if defined in the file",
+				flags: func(flagSet *flag.FlagSet) {
+					flagSet.String("f", "", "the definition file of the form")
+					flagSet.String("design-id", "", "the ID of the design of the form, instead of the design named in the file")
+				},
+				run: runFormsCreate,
+			},
+			{name: "get", usage: "FORM_ID", args: 1, description: "Get a form", run: runFormsGet},
+		},
+	},
+	{
+		name:        "preview",
+		usage:       "-f FILE",
+		description: "Preview a form definition file in the browser, reloading it when the file changes",
+		flags: func(flagSet *flag.FlagSet) {
+			flagSet.String("f", "", "the definition file of the form")
+			flagSet.String("addr", "localhost:8080", "the address to serve the preview on")
+			flagSet.String("html", "", "write the preview to this HTML file instead of serving it")
+		},
+		run: runPreview,
+	},
+	{
+		name:        "run",
+		usage:       "-f FILE",
+		description: "Answer a form definition file in the terminal, and print the answers as a response of the API",
+		flags: func(flagSet *flag.FlagSet) {
+			flagSet.String("f", "", "the definition file of the form")
+		},
+		run: runRun,
+	},
+	{
+		name: "images",
+		subcommands: []*command{
+			{name: "create", usage: "IMAGE_URL", args: 1, description: "Upload the image at IMAGE_URL", run: runImagesCreate},
+			{name: "get", usage: "IMAGE_ID", args: 1, description: "Get an image", run: runImagesGet},
+		},
+	},
+	{
+		name: "designs",
+		subcommands: []*command{
+			{
+				name:        "create",
+				usage:       "[--font FONT] [--question COLOR] [--button COLOR] [--answer COLOR] [--background COLOR]",
+				description: "Create a design",
+				flags: func(flagSet *flag.FlagSet) {
+					flagSet.String("font", "", "the font of the design")
+					flagSet.String("question", "", "the color of the questions, e.g. #3D3D3D")
+					flagSet.String("button", "", "the color of the buttons")
+					flagSet.String("answer", "", "the color of the answers")
+					flagSet.String("background", "", "the color of the background")
+				},
+				run: runDesignsCreate,
+			},
+			{name: "get", usage: "DESIGN_ID", args: 1, description: "Get a design", run: runDesignsGet},
+		},
+	},
+	{
+		name: "urls",
+		subcommands: []*command{
+			{name: "create", usage: "FORM_ID", args: 1, description: "Create a URL linking to a form", run: runURLsCreate},
+			{name: "get", usage: "URL_ID", args: 1, description: "Get a URL", run: runURLsGet},
+			{name: "point", usage: "URL_ID FORM_ID", args: 2, description: "Change the form a URL links to", run: runURLsPoint},
+			{name: "delete", usage: "URL_ID", args: 1, description: "Delete a URL", run: runURLsDelete},
+		},
+	},
+}
+
+// flagValue returns the value of a flag of a command
+func flagValue(flagSet *flag.FlagSet, name string) string {
+	return flagSet.Lookup(name).Value.String()
+}
+
+func runInfo(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	baseInfo, err := client.BaseInfoWithContext(cli.ctx)
+	if err != nil {
+		return err
+	}
+	return cli.print(baseInfo)
+}
+
+func runFormsCreate(cli *cli, flagSet *flag.FlagSet) error {
+	path := flagValue(flagSet, "f")
+	if path == "" {
+		flagSet.Usage()
+		return errUsage
+	}
+	definition, err := typeform.LoadFormDefinition(path)
+	if err != nil {
+		return err
+	}
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+
+	form := definition.Form
+	if designID := flagValue(flagSet, "design-id"); designID != "" {
+		form.DesignID = designID
+	} else if definition.DesignName != "" {
+		design, ok := definition.Designs[definition.DesignName]
+		if !ok {
+			return fmt.Errorf("design %q is not defined in %s; use --design-id", definition.DesignName, path)
+		}
+		designInfo, err := client.CreateDesignWithContext(cli.ctx, design)
+		if err != nil {
+			return fmt.Errorf("design %q: %s", definition.DesignName, err)
+		}
+		form, err = definition.Resolve(map[string]string{definition.DesignName: designInfo.ID})
+		if err != nil {
+			return err
+		}
+	}
+
+	formInfo, err := client.CreateFormWithContext(cli.ctx, form)
+	if err != nil {
+		return err
+	}
+	return cli.print(formInfo)
+}
+
+func runFormsGet(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	formInfo, err := client.GetFormWithContext(cli.ctx, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	return cli.print(formInfo)
+}
+
+func runRun(cli *cli, flagSet *flag.FlagSet) error {
+	path := flagValue(flagSet, "f")
+	if path == "" {
+		flagSet.Usage()
+		return errUsage
+	}
+	definition, err := typeform.LoadFormDefinition(path)
+	if err != nil {
+		return err
+	}
+
+	// the questions are written to the standard error, so that the standard output only holds the response
+	response, err := typeform.RunForm(definition.Form, cli.stdin, cli.stderr)
+	if err != nil {
+		return err
+	}
+	if cli.output == "table" {
+		// the answers of a response don't fit in a table
+		cli.output = "json"
+	}
+	return cli.print(response)
+}
+
+func runImagesCreate(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	newImage, err := client.CreateImageWithContext(cli.ctx, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	return cli.print(newImage)
+}
+
+func runImagesGet(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	imageInfo, err := client.GetImageWithContext(cli.ctx, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	return cli.print(imageInfo)
+}
+
+func runDesignsCreate(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	design := typeform.Design{
+		Font: flagValue(flagSet, "font"),
+		Colors: typeform.Colors{
+			Question:   flagValue(flagSet, "question"),
+			Button:     flagValue(flagSet, "button"),
+			Answer:     flagValue(flagSet, "answer"),
+			Background: flagValue(flagSet, "background"),
+		},
+	}
+	designInfo, err := client.CreateDesignWithContext(cli.ctx, design)
+	if err != nil {
+		return err
+	}
+	return cli.print(designInfo)
+}
+
+func runDesignsGet(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	designInfo, err := client.GetDesignWithContext(cli.ctx, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	return cli.print(designInfo)
+}
+
+func runURLsCreate(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	URLInfo, err := client.CreateURLWithContext(cli.ctx, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	return cli.print(URLInfo)
+}
+
+func runURLsGet(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	URLInfo, err := client.GetURLWithContext(cli.ctx, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	return cli.print(URLInfo)
+}
+
+func runURLsPoint(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	URLInfo, err := client.ModifyURLWithContext(cli.ctx, flagSet.Arg(0), flagSet.Arg(1))
+	if err != nil {
+		return err
+	}
+	return cli.print(URLInfo)
+}
+
+func runURLsDelete(cli *cli, flagSet *flag.FlagSet) error {
+	client, err := cli.apiClient()
+	if err != nil {
+		return err
+	}
+	URLID := flagSet.Arg(0)
+	err = client.DeleteURLWithContext(cli.ctx, URLID)
+	if err != nil {
+		return err
+	}
+	return cli.print(map[string]interface{}{"id": URLID, "deleted": true})
+}
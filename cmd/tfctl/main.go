@@ -0,0 +1,264 @@
+// Command tfctl is a command-line client of the Typeform I/O API.
+//
+// Usage:
+//
+//	tfctl [global flags] <command> [<subcommand>] [flags] [arguments]
+//
+// Commands:
+//
+//	info                                  Get info about the API
+//	forms create -f form.yaml             Create a form from a definition file
+//	forms get FORM_ID                     Get a form
+//	preview -f form.yaml                  Preview a form definition file, live-reloading it
+//	run -f form.yaml                      Answer a form definition file in the terminal
+//	images create IMAGE_URL               Upload an image
+//	images get IMAGE_ID                   Get an image
+//	designs create [--font ...]           Create a design
+//	designs get DESIGN_ID                 Get a design
+//	urls create FORM_ID                   Create a URL linking to a form
+//	urls get URL_ID                       Get a URL
+//	urls point URL_ID FORM_ID             Change the form a URL links to
+//	urls delete URL_ID                    Delete a URL
+//
+// The API token is read from the --token flag, the TYPEFORM_API_TOKEN environment variable,
+// or the "token" key of the config file (by default $XDG_CONFIG_HOME/tfctl/config.yaml).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+	"gopkg.in/yaml.v3"
+)
+
+// TokenEnv is the environment variable holding the API token
+const TokenEnv = "TYPEFORM_API_TOKEN"
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// cli is the state shared by the commands
+type cli struct {
+	ctx    context.Context
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	token      string
+	configPath string
+	baseURL    string
+	output     string
+
+	client *typeform.Client
+}
+
+// command is a (sub)command of tfctl
+type command struct {
+	name        string
+	usage       string // The arguments, after the name of the command
+	args        int    // The number of arguments
+	description string
+	flags       func(flagSet *flag.FlagSet) // Registers the flags of the command, if any
+	run         func(cli *cli, flagSet *flag.FlagSet) error
+	subcommands []*command
+}
+
+// errUsage is returned when a command is misused; the usage has already been printed
+var errUsage = errors.New("usage error")
+
+// run runs tfctl with args, and returns its exit code
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cli := &cli{ctx: ctx, stdin: stdin, stdout: stdout, stderr: stderr, output: "table"}
+
+	flagSet := flag.NewFlagSet("tfctl", flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	cli.registerGlobalFlags(flagSet)
+	flagSet.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: tfctl [global flags] <command> [<subcommand>] [flags] [arguments]")
+		fmt.Fprintln(stderr, "\nCommands:")
+		printCommands(stderr, commands, "  ")
+		fmt.Fprintln(stderr, "\nGlobal flags:")
+		flagSet.PrintDefaults()
+	}
+	err := flagSet.Parse(args)
+	if err == flag.ErrHelp {
+		return 0
+	}
+	if err != nil {
+		return 2
+	}
+
+	err = cli.dispatch(commands, flagSet.Args(), "tfctl")
+	switch {
+	case err == nil:
+		return 0
+	case err == errUsage:
+		return 2
+	default:
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+}
+
+func (cli *cli) registerGlobalFlags(flagSet *flag.FlagSet) {
+	flagSet.StringVar(&cli.token, "token", cli.token, "the API token (default $"+TokenEnv+" or the token of the config file)")
+	flagSet.StringVar(&cli.configPath, "config", cli.configPath, "the config file (default $XDG_CONFIG_HOME/tfctl/config.yaml)")
+	flagSet.StringVar(&cli.baseURL, "base-url", cli.baseURL, "the base URL of the API")
+	flagSet.StringVar(&cli.output, "output", cli.output, "the output format: json, yaml or table")
+}
+
+func printCommands(w io.Writer, commands []*command, indent string) {
+	for _, command := range commands {
+		if len(command.subcommands) > 0 {
+			fmt.Fprintf(w, "%s%s\n", indent, command.name)
+			printCommands(w, command.subcommands, indent+"  ")
+			continue
+		}
+		fmt.Fprintf(w, "%s%-36s %s\n", indent, strings.TrimSpace(command.name+" "+command.usage), command.description)
+	}
+}
+
+// dispatch finds the command named by the first argument, and runs it with the other arguments
+func (cli *cli) dispatch(commands []*command, args []string, prefix string) error {
+	if len(args) == 0 {
+		fmt.Fprintf(cli.stderr, "Usage: %s <command>\n\nCommands:\n", prefix)
+		printCommands(cli.stderr, commands, "  ")
+		return errUsage
+	}
+
+	for _, command := range commands {
+		if command.name != args[0] {
+			continue
+		}
+		name := prefix + " " + command.name
+		if len(command.subcommands) > 0 {
+			return cli.dispatch(command.subcommands, args[1:], name)
+		}
+
+		flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+		flagSet.SetOutput(cli.stderr)
+		// the global flags can also be given after the command
+		cli.registerGlobalFlags(flagSet)
+		if command.flags != nil {
+			command.flags(flagSet)
+		}
+		flagSet.Usage = func() {
+			fmt.Fprintf(cli.stderr, "Usage: %s [flags] %s\n\n%s\n\nFlags:\n", name, command.usage, command.description)
+			flagSet.PrintDefaults()
+		}
+		err := flagSet.Parse(args[1:])
+		if err == flag.ErrHelp {
+			return nil
+		}
+		if err != nil {
+			return errUsage
+		}
+		if flagSet.NArg() != command.args {
+			flagSet.Usage()
+			return errUsage
+		}
+		// an invalid format is reported before the command makes any change
+		err = checkOutputFormat(cli.output)
+		if err != nil {
+			fmt.Fprintln(cli.stderr, err)
+			return errUsage
+		}
+		return command.run(cli, flagSet)
+	}
+
+	fmt.Fprintf(cli.stderr, "Unknown command %q\n\nCommands:\n", strings.TrimSpace(prefix+" "+args[0]))
+	printCommands(cli.stderr, commands, "  ")
+	return errUsage
+}
+
+// config is the content of the config file
+type config struct {
+	Token   string `yaml:"token"`
+	BaseURL string `yaml:"base_url"`
+}
+
+func defaultConfigPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "tfctl", "config.yaml")
+}
+
+// loadConfig reads the config file; a missing default config file is an empty config
+func (cli *cli) loadConfig() (config, error) {
+	var loaded config
+	path := cli.configPath
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	if path == "" {
+		return loaded, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && cli.configPath == "" {
+		return loaded, nil
+	}
+	if err != nil {
+		return loaded, err
+	}
+	err = yaml.Unmarshal(data, &loaded)
+	if err != nil {
+		return loaded, fmt.Errorf("%s: %s", path, err)
+	}
+	return loaded, nil
+}
+
+// apiClient returns the client of the API, authenticated with the token of the flags, environment or config file
+func (cli *cli) apiClient() (*typeform.Client, error) {
+	if cli.client != nil {
+		return cli.client, nil
+	}
+
+	loaded, err := cli.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	token := cli.token
+	if token == "" {
+		token = os.Getenv(TokenEnv)
+	}
+	if token == "" {
+		token = loaded.Token
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no API token: use --token, $%s or the token of the config file", TokenEnv)
+	}
+	baseURL := cli.baseURL
+	if baseURL == "" {
+		baseURL = loaded.BaseURL
+	}
+
+	options := []typeform.ClientOption{typeform.WithUserAgent("tfctl " + typeform.DefaultUserAgent)}
+	if baseURL != "" {
+		options = append(options, typeform.WithBaseURL(baseURL))
+	}
+	client, err := typeform.NewClient(typeform.Latest, options...)
+	if err != nil {
+		return nil, err
+	}
+	err = client.SetAPIToken(token)
+	if err != nil {
+		return nil, err
+	}
+	cli.client = client
+	return client, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/go-ask-awesomely/typeformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// tfctl runs the command with the flags pointing it to server, and returns its exit code and outputs
+func tfctl(t *testing.T, server *typeformtest.Server, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	ioutil.WriteFile(configPath, nil, 0644)
+	args = append([]string{"--config", configPath, "--base-url", server.URL, "--token", server.Token()}, args...)
+	code := run(context.Background(), args, strings.NewReader(""), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	server := typeformtest.NewServer()
+	defer server.Close()
+
+	definition := filepath.Join(t.TempDir(), "form.yaml")
+	ioutil.WriteFile(definition, []byte(`
+title: Survey
+design: brand
+designs:
+  brand: {font: Arial}
+fields:
+  - type: short_text
+    question: Name?
+`), 0644)
+
+	code, stdout, stderr := tfctl(t, server, "--output", "json", "forms", "create", "-f", definition)
+	assert.Equal(t, 0, code, stderr)
+	var formInfo struct {
+		ID   string `json:"id"`
+		URLs []struct {
+			ID string `json:"id"`
+		} `json:"urls"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &formInfo))
+	form, ok := server.Form(formInfo.ID)
+	assert.True(t, ok, "the form should be created")
+	assert.NotEmpty(t, form.DesignID, "the design of the file should be created")
+
+	code, stdout, _ = tfctl(t, server, "forms", "get", formInfo.ID)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "TITLE")
+	assert.Contains(t, stdout, "Survey")
+
+	code, stdout, _ = tfctl(t, server, "--output", "yaml", "urls", "get", formInfo.URLs[0].ID)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "form_id: "+formInfo.ID)
+
+	code, _, _ = tfctl(t, server, "urls", "delete", formInfo.URLs[0].ID)
+	assert.Equal(t, 0, code)
+	code, _, stderr = tfctl(t, server, "urls", "get", formInfo.URLs[0].ID)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "url not found")
+}
+
+func TestUsage(t *testing.T) {
+	server := typeformtest.NewServer()
+	defer server.Close()
+
+	code, _, stderr := tfctl(t, server, "urls", "point", "only-one-arg")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "Usage: tfctl urls point")
+
+	code, _, stderr = tfctl(t, server, "forms", "delete")
+	assert.Equal(t, 2, code)
+	assert.True(t, strings.Contains(stderr, `Unknown command "tfctl forms delete"`), stderr)
+
+	// an unknown output format is reported before the command runs
+	requests := server.Requests()
+	code, _, stderr = tfctl(t, server, "--output", "xml", "urls", "delete", "abc")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown output format "xml"`)
+	assert.Equal(t, requests, server.Requests(), "no request should be made")
+
+	var stdout bytes.Buffer
+	t.Setenv(TokenEnv, "")
+	code = run(context.Background(), []string{"--config", filepath.Join(t.TempDir(), "none.yaml"), "info"}, strings.NewReader(""), &stdout, &stdout)
+	assert.Equal(t, 1, code, "a missing explicit config file should be reported")
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	ioutil.WriteFile(configPath, []byte("token: "+server.Token()+"\nbase_url: "+server.URL+"\n"), 0644)
+	stdout.Reset()
+	code = run(context.Background(), []string{"--config", configPath, "--output", "json", "info"}, strings.NewReader(""), &stdout, &stdout)
+	assert.Equal(t, 0, code, stdout.String())
+	assert.Contains(t, stdout.String(), typeformtest.APIVersion, "the token and base URL should be read from the config file")
+}
+
+func TestRun(t *testing.T) {
+	definition := filepath.Join(t.TempDir(), "form.yaml")
+	ioutil.WriteFile(definition, []byte("title: Survey\nfields:\n  - {type: yes_no, question: \"Happy?\", ref: happy}\n"), 0644)
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"run", "-f", definition}, strings.NewReader("y\n"), &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stderr.String(), "1 → Happy?", "the questions should be written to the standard error")
+
+	var response map[string]interface{}
+	assert.Nil(t, json.Unmarshal(stdout.Bytes(), &response), "the response should be written as JSON")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"field":   map[string]interface{}{"id": "happy", "type": "yes_no", "ref": "happy"},
+		"type":    "boolean",
+		"boolean": true,
+	}}, response["answers"])
+
+	code = run(context.Background(), []string{"run", "-f", definition}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code, "an incomplete form should be an error")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// print writes value to the standard output, in the format of the --output flag
+func (cli *cli) print(value interface{}) error {
+	// the value is converted to generic JSON values first, so that all the formats use the JSON names
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	if err != nil {
+		return err
+	}
+
+	switch cli.output {
+	case "json":
+		encoder := json.NewEncoder(cli.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(generic)
+	case "yaml":
+		encoder := yaml.NewEncoder(cli.stdout)
+		encoder.SetIndent(2)
+		err = encoder.Encode(generic)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "table":
+		return printTable(cli, generic)
+	default:
+		return checkOutputFormat(cli.output)
+	}
+}
+
+// checkOutputFormat returns an error if output is not a format of the --output flag
+func checkOutputFormat(output string) error {
+	switch output {
+	case "json", "yaml", "table":
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q: use json, yaml or table", output)
+	}
+}
+
+// printTable writes the properties of an object as a two-column table;
+// nested values are written as compact JSON
+func printTable(cli *cli, value interface{}) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		_, err := fmt.Fprintln(cli.stdout, tableCell(value))
+		return err
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	writer := tabwriter.NewWriter(cli.stdout, 0, 4, 2, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(writer, "%s\t%s\n", strings.ToUpper(key), tableCell(object[key]))
+	}
+	return writer.Flush()
+}
+
+func tableCell(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		return typed
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(typed)
+		return string(data)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
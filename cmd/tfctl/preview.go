@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+)
+
+// previewHandler serves the preview of the form defined in the file at path, reloading it on each request:
+// "/" is the preview, and "/version" a hash of the file, polled by the preview to reload itself when it changes
+func previewHandler(path string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		hash := sha256.Sum256(data)
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprint(w, hex.EncodeToString(hash[:]))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		options := []typeform.PreviewOption{typeform.WithLiveReload("/version")}
+
+		var page bytes.Buffer
+		definition, err := typeform.LoadFormDefinition(path)
+		if err != nil {
+			err = typeform.RenderPreviewError(&page, err, options...)
+		} else {
+			if design, ok := definition.Designs[definition.DesignName]; ok {
+				options = append(options, typeform.WithPreviewDesign(design))
+			}
+			err = typeform.RenderPreview(&page, definition.Form, options...)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		page.WriteTo(w)
+	})
+	return mux
+}
+
+func runPreview(cli *cli, flagSet *flag.FlagSet) error {
+	path := flagValue(flagSet, "f")
+	if path == "" {
+		flagSet.Usage()
+		return errUsage
+	}
+
+	if output := flagValue(flagSet, "html"); output != "" {
+		// write a static page instead of serving it
+		definition, err := typeform.LoadFormDefinition(path)
+		if err != nil {
+			return err
+		}
+		var options []typeform.PreviewOption
+		if design, ok := definition.Designs[definition.DesignName]; ok {
+			options = append(options, typeform.WithPreviewDesign(design))
+		}
+		var page bytes.Buffer
+		err = typeform.RenderPreview(&page, definition.Form, options...)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(output, page.Bytes(), 0644)
+	}
+
+	listener, err := net.Listen("tcp", flagValue(flagSet, "addr"))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: previewHandler(path)}
+	fmt.Fprintf(cli.stderr, "Previewing %s at http://%s/ (reloads when the file changes; Ctrl+C to stop)\n", path, listener.Addr())
+
+	go func() {
+		<-cli.ctx.Done()
+		server.Close()
+	}()
+	err = server.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func get(t *testing.T, handler http.Handler, path string) string {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+func TestPreviewHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "form.yaml")
+	ioutil.WriteFile(path, []byte("title: Survey\ndesign: dark\ndesigns: {dark: {colors: {background: \"#111111\"}}}\nfields: [{type: email, question: Email}]\n"), 0644)
+	handler := previewHandler(path)
+
+	page := get(t, handler, "/")
+	assert.Contains(t, page, "<h1>Survey</h1>")
+	assert.Contains(t, page, "#111111", "the design of the file should be used")
+	version := get(t, handler, "/version")
+
+	ioutil.WriteFile(path, []byte("title: Survey\nfields: []\n"), 0644)
+	assert.NotEqual(t, version, get(t, handler, "/version"), "the version should change with the file")
+	assert.Contains(t, get(t, handler, "/"), "at least one field is required", "the errors of the file should be shown")
+}
@@ -3,6 +3,7 @@ package typeform
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,13 +19,29 @@ import (
 // APIDomain is the domain of the typeform API
 var APIDomain = "https://api.typeform.io/"
 
-// NewClient creates a new API client
-func NewClient(APIVersion APIVersion) (*Client, error) {
-	return &Client{
-		httpClient: http.DefaultClient,
-		apiVersion: APIVersion,
-		mu:         &sync.RWMutex{},
-	}, nil
+// DefaultUserAgent is the User-Agent sent by clients that don't set one with WithUserAgent
+const DefaultUserAgent = "github.com/gagliardetto/go-ask-awesomely"
+
+// NewClient creates a new API client; the options are applied in order.
+// Requests with idempotent methods are retried with DefaultRetryPolicy, unless WithRetryPolicy sets another policy.
+func NewClient(APIVersion APIVersion, options ...ClientOption) (*Client, error) {
+	client := &Client{
+		httpClient:  http.DefaultClient,
+		apiVersion:  APIVersion,
+		userAgent:   DefaultUserAgent,
+		headers:     http.Header{},
+		mu:          &sync.RWMutex{},
+		retryPolicy: DefaultRetryPolicy,
+	}
+
+	for _, option := range options {
+		err := option(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
 }
 
 // SetAPIToken sets the API token used for making the requests to the API
@@ -40,32 +57,155 @@ func (client *Client) SetAPIToken(token string) error {
 	return nil
 }
 
-func (client *Client) fetchAndReturnPage(path string, method string, headers http.Header, queryParameters url.Values, bodyPayload interface{}) ([]byte, http.Header, error) {
+func (client *Client) fetchAndReturnPage(ctx context.Context, path string, method string, headers http.Header, queryParameters url.Values, bodyPayload interface{}) ([]byte, http.Header, error) {
 
-	if client.config.APIKey == "" {
-		return []byte(""), http.Header{}, fmt.Errorf("%s", "APIKey not provided")
+	responseReader, responseHeaders, err := client.openPage(ctx, path, method, headers, queryParameters, bodyPayload)
+	if err != nil {
+		return []byte(""), http.Header{}, err
 	}
+	defer responseReader.Close()
 
-	requestURL, err := url.Parse(APIDomain)
+	responseBody, err := ioutil.ReadAll(responseReader)
 	if err != nil {
 		return []byte(""), http.Header{}, err
 	}
+
+	return responseBody, responseHeaders, nil
+}
+
+// RawRequest makes a request to an API path (e.g. "/latest/forms/<form ID>") with the
+// provided query parameters and JSON body payload, and returns the (decompressed) body of the
+// response without reading it, so that it can be piped elsewhere; the caller must close it.
+// Non-2xx responses are returned as *ResponseError, like for the other methods.
+func (client *Client) RawRequest(ctx context.Context, method string, path string, queryParameters url.Values, bodyPayload interface{}) (io.ReadCloser, http.Header, error) {
+	if queryParameters == nil {
+		queryParameters = url.Values{}
+	}
+	return client.openPage(ctx, path, method, http.Header{}, queryParameters, bodyPayload)
+}
+
+// fetchAndDecode is like fetchAndReturnPage, but decodes the JSON response body
+// into result while reading it, without holding the whole body in memory
+func (client *Client) fetchAndDecode(ctx context.Context, path string, method string, headers http.Header, queryParameters url.Values, bodyPayload interface{}, result interface{}) (http.Header, error) {
+
+	responseReader, responseHeaders, err := client.openPage(ctx, path, method, headers, queryParameters, bodyPayload)
+	if err != nil {
+		return http.Header{}, err
+	}
+	defer responseReader.Close()
+
+	err = json.NewDecoder(responseReader).Decode(result)
+	if err != nil {
+		return http.Header{}, err
+	}
+
+	return responseHeaders, nil
+}
+
+// openPage makes the request, retrying it according to the retry policy, and returns
+// the (decompressed) body of the successful response, which must be closed by the caller;
+// retries only happen before the body is returned
+func (client *Client) openPage(ctx context.Context, path string, method string, headers http.Header, queryParameters url.Values, bodyPayload interface{}) (io.ReadCloser, http.Header, error) {
+
+	if client.config.APIKey == "" {
+		return nil, http.Header{}, fmt.Errorf("%s", "APIKey not provided")
+	}
+
+	baseURL := client.baseURL
+	if baseURL == "" {
+		baseURL = APIDomain
+	}
+
+	requestURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, http.Header{}, err
+	}
 	requestURL.Path = path
 	requestURL.RawQuery = queryParameters.Encode()
 
 	if method != "GET" && method != "POST" && method != "PUT" && method != "PATCH" && method != "DELETE" {
-		return []byte(""), http.Header{}, fmt.Errorf("Method not supported: %v", method)
+		return nil, http.Header{}, fmt.Errorf("Method not supported: %v", method)
 	}
 
 	encodedBody, err := json.Marshal(bodyPayload)
 	if err != nil {
-		return []byte(""), http.Header{}, err
+		return nil, http.Header{}, err
+	}
+
+	if key := idempotencyKeyFromContext(ctx); key != "" && headers.Get(IdempotencyKeyHeader) == "" {
+		headers.Set(IdempotencyKeyHeader, key)
+	}
+	for key, values := range client.headers {
+		if _, ok := headers[key]; ok {
+			continue
+		}
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+
+	canRetry := client.retryPolicy.allowsMethod(method, headers)
+
+	for attempt := 1; ; attempt++ {
+		if client.rateLimiter != nil {
+			err = client.rateLimiter.Wait(ctx)
+			if err != nil {
+				return nil, http.Header{}, err
+			}
+		}
+
+		response, responseReader, err := client.doRequest(ctx, method, requestURL, headers.Clone(), encodedBody)
+		if observer, ok := client.rateLimiter.(RateLimitObserver); ok && response != nil {
+			observer.Observe(response.Header)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, http.Header{}, ctx.Err()
+			}
+			if canRetry && attempt < client.retryPolicy.MaxAttempts {
+				err = sleepContext(ctx, client.retryPolicy.backoff(attempt, nil))
+				if err != nil {
+					return nil, http.Header{}, err
+				}
+				continue
+			}
+			return nil, http.Header{}, err
+		}
+
+		if canRetry && attempt < client.retryPolicy.MaxAttempts && isRetryableStatus(response.StatusCode) {
+			// drain the body, so that the connection can be reused
+			io.Copy(ioutil.Discard, responseReader)
+			responseReader.Close()
+
+			err = sleepContext(ctx, client.retryPolicy.backoff(attempt, response.Header))
+			if err != nil {
+				return nil, http.Header{}, err
+			}
+			continue
+		}
+
+		if response.StatusCode > 299 || response.StatusCode < 199 {
+			responseBody, err := ioutil.ReadAll(responseReader)
+			responseReader.Close()
+			if err != nil {
+				return nil, http.Header{}, err
+			}
+			//fmt.Println(string(responseBody))
+			return nil, http.Header{}, newResponseError(method, path, response, responseBody)
+		}
+
+		return responseReader, response.Header, nil
 	}
+}
+
+// doRequest makes a single attempt at a request, and returns the response with a reader
+// of its decompressed body, which must be closed by the caller
+func (client *Client) doRequest(ctx context.Context, method string, requestURL *url.URL, headers http.Header, encodedBody []byte) (*http.Response, io.ReadCloser, error) {
 
 	//fmt.Println(requestURL.String())
-	request, err := http.NewRequest(method, requestURL.String(), bytes.NewBuffer(encodedBody))
+	request, err := http.NewRequestWithContext(ctx, method, requestURL.String(), bytes.NewReader(encodedBody))
 	if err != nil {
-		return []byte(""), http.Header{}, fmt.Errorf("Failed to get the URL %s: %s", requestURL, err)
+		return nil, nil, fmt.Errorf("Failed to get the URL %s: %s", requestURL, err)
 	}
 	request.Header = headers
 	request.Header.Add("Content-Length", strconv.Itoa(len(encodedBody)))
@@ -73,43 +213,35 @@ func (client *Client) fetchAndReturnPage(path string, method string, headers htt
 	request.Header.Add("Connection", "Keep-Alive")
 	request.Header.Add("Accept-Encoding", "gzip")
 	request.Header.Add("Content-Type", "application/json")
-	request.Header.Add("User-Agent", "github.com/gagliardetto/go-ask-awesomely")
+	request.Header.Add("User-Agent", client.userAgent)
 	request.Header.Add("X-API-TOKEN", client.config.APIKey)
 
 	response, err := client.httpClient.Do(request)
 	if err != nil {
-		return []byte(""), http.Header{}, fmt.Errorf("Failed to get the URL %s: %s", requestURL, err)
+		return nil, nil, fmt.Errorf("Failed to get the URL %s: %s", requestURL, err)
 	}
-	defer response.Body.Close()
 
-	var responseReader io.ReadCloser
-	if strings.Contains(response.Header.Get("Content-Encoding"), "gzip") {
-		decompressedBodyReader, err := gzip.NewReader(response.Body)
-		if err != nil {
-			return []byte(""), http.Header{}, err
-		}
-		responseReader = decompressedBodyReader
-		defer responseReader.Close()
-	} else {
-		responseReader = response.Body
+	if !strings.Contains(response.Header.Get("Content-Encoding"), "gzip") {
+		return response, response.Body, nil
 	}
 
-	responseBody, err := ioutil.ReadAll(responseReader)
+	decompressedBodyReader, err := gzip.NewReader(response.Body)
 	if err != nil {
-		return []byte(""), http.Header{}, err
+		response.Body.Close()
+		return nil, nil, err
 	}
+	return response, &gzipBody{Reader: decompressedBodyReader, body: response.Body}, nil
+}
 
-	if response.StatusCode > 299 || response.StatusCode < 199 {
-		var apiError APIError
-		err = json.Unmarshal(responseBody, &apiError)
-		if err != nil {
-			return []byte(""), http.Header{}, nil
-		}
-		//fmt.Println(string(responseBody))
-		return []byte(""), http.Header{}, fmt.Errorf("HTTPStatus %s: %s", strconv.Itoa(response.StatusCode), apiError.String())
-	}
+// gzipBody reads a gzipped response body, and closes both the decompressor and the body
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
 
-	return responseBody, response.Header, nil
+func (gzipBody *gzipBody) Close() error {
+	gzipBody.Reader.Close()
+	return gzipBody.body.Close()
 }
 
 func (apiError *APIError) String() string {
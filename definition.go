@@ -0,0 +1,361 @@
+package typeform
+
+import (
+	_ "embed" // for FormDefinitionSchema
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormDefinitionSchema is the JSON Schema of the form definition files read by LoadFormDefinition
+//
+//go:embed form.schema.json
+var FormDefinitionSchema []byte
+
+// FormDefinition is a form read from a definition file, e.g.
+//
+//	title: Customer survey
+//	tags: [customers]
+//	design: brand
+//	designs:
+//	  brand:
+//	    font: Source Sans Pro
+//	    colors: {question: "#3D3D3D", button: "#4FB0AE", answer: "#4FB0AE", background: "#FFFFFF"}
+//	fields:
+//	  - type: yes_no
+//	    ref: happy
+//	    question: Are you happy with us?
+//	  - type: multiple_choice
+//	    question: What could we do better?
+//	    choices: [Prices, Support, Delivery]
+//	logic_jumps:
+//	  - {from: happy, if: true, to: thanks}
+//	  ...
+type FormDefinition struct {
+	Form       Form              // The form; its DesignID is set by Resolve
+	DesignName string            // The name of the design of the form, if any; it's one of Designs
+	Designs    map[string]Design // The designs defined in the file, by name
+}
+
+// Resolve returns the form, with the DesignID of the design named by DesignName
+func (definition *FormDefinition) Resolve(designIDs map[string]string) (Form, error) {
+	form := definition.Form
+	if definition.DesignName == "" {
+		return form, nil
+	}
+	designID, ok := designIDs[definition.DesignName]
+	if !ok {
+		return form, fmt.Errorf("unknown design %q", definition.DesignName)
+	}
+	form.DesignID = designID
+	return form, nil
+}
+
+// DefinitionError is an error at a position of a form definition file
+type DefinitionError struct {
+	File    string
+	Line    int
+	Column  int
+	Path    string // The path of the offending part, in the same format as Violation.Path
+	Message string
+}
+
+func (definitionError DefinitionError) Error() string {
+	position := fmt.Sprintf("%s:%d:%d", definitionError.File, definitionError.Line, definitionError.Column)
+	if definitionError.Path == "" {
+		return fmt.Sprintf("%s: %s", position, definitionError.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", position, definitionError.Path, definitionError.Message)
+}
+
+// DefinitionErrors is the error returned when a form definition file is invalid; it contains all the errors found
+type DefinitionErrors []DefinitionError
+
+func (definitionErrors DefinitionErrors) Error() string {
+	messages := make([]string, len(definitionErrors))
+	for i, definitionError := range definitionErrors {
+		messages[i] = definitionError.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// LoadFormDefinition reads a form definition from a YAML or JSON file
+func LoadFormDefinition(path string) (*FormDefinition, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFormDefinition(path, data)
+}
+
+// ParseFormDefinition parses a form definition in YAML or JSON;
+// filename is only used in the errors. The design must be defined in designs, and the form is checked
+// with Form.Validate; the violations are reported at their position in the definition
+func ParseFormDefinition(filename string, data []byte) (*FormDefinition, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, DefinitionErrors{syntaxError(filename, err)}
+	}
+
+	decoder := &definitionDecoder{
+		file:      filename,
+		positions: map[string]*yaml.Node{},
+	}
+	if len(document.Content) == 0 {
+		return nil, DefinitionErrors{{File: filename, Line: 1, Column: 1, Message: "the definition is empty"}}
+	}
+	root := resolveAlias(document.Content[0])
+	if root.Kind != yaml.MappingNode {
+		decoder.fail(root, "", "expected a mapping, got %s", kindName(root))
+		return nil, decoder.errors
+	}
+
+	var definition definitionFile
+	decoder.decode(root, reflect.ValueOf(&definition).Elem(), "")
+	if _, ok := definition.Designs[definition.Design]; definition.Design != "" && !ok {
+		decoder.fail(decoder.nodeAt("design", root), "design", "design %q is not defined in designs", definition.Design)
+	}
+	if len(decoder.errors) > 0 {
+		return nil, decoder.errors
+	}
+
+	err = definition.Form.Validate()
+	if validationErrors, ok := err.(ValidationErrors); ok {
+		for _, violation := range validationErrors {
+			decoder.fail(decoder.nodeAt(violation.Path, root), violation.Path, "%s", violation.Message)
+		}
+		return nil, decoder.errors
+	}
+
+	return &FormDefinition{
+		Form:       definition.Form,
+		DesignName: definition.Design,
+		Designs:    definition.Designs,
+	}, nil
+}
+
+// definitionFile is the content of a form definition file
+type definitionFile struct {
+	Form
+	Design  string            `json:"design,omitempty"`
+	Designs map[string]Design `json:"designs,omitempty"`
+}
+
+// syntaxError converts a YAML syntax error to a DefinitionError
+func syntaxError(filename string, err error) DefinitionError {
+	definitionError := DefinitionError{File: filename, Line: 1, Column: 1, Message: err.Error()}
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	var line int
+	if n, _ := fmt.Sscanf(message, "line %d:", &line); n == 1 {
+		definitionError.Line = line
+		definitionError.Message = strings.TrimSpace(message[strings.Index(message, ":")+1:])
+	}
+	return definitionError
+}
+
+// definitionDecoder decodes YAML nodes into Go values using their json tags,
+// recording the position of each path
+type definitionDecoder struct {
+	file      string
+	errors    DefinitionErrors
+	positions map[string]*yaml.Node
+}
+
+func (decoder *definitionDecoder) fail(node *yaml.Node, path string, format string, args ...interface{}) {
+	decoder.errors = append(decoder.errors, DefinitionError{
+		File:    decoder.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// nodeAt returns the node of the longest recorded prefix of path
+func (decoder *definitionDecoder) nodeAt(path string, root *yaml.Node) *yaml.Node {
+	for path != "" {
+		if node, ok := decoder.positions[path]; ok {
+			return node
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return root
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func kindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonName returns the name of a struct field in JSON, or "" if it is not marshaled
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// structFields returns the fields of a struct type by JSON name, including the fields of embedded structs
+func structFields(structType reflect.Type) map[string][]int {
+	fields := map[string][]int{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, index := range structFields(field.Type) {
+				fields[name] = append([]int{i}, index...)
+			}
+			continue
+		}
+		if name := jsonName(field); name != "" {
+			fields[name] = []int{i}
+		}
+	}
+	return fields
+}
+
+func (decoder *definitionDecoder) decode(node *yaml.Node, value reflect.Value, path string) {
+	node = resolveAlias(node)
+	if path != "" {
+		decoder.positions[path] = node
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+			return
+		}
+		value.Set(reflect.New(value.Type().Elem()))
+		decoder.decode(node, value.Elem(), path)
+
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(Choice{}) && node.Kind == yaml.ScalarNode {
+			// a choice can be written as its label
+			value.Set(reflect.ValueOf(Choice{Label: node.Value}))
+			return
+		}
+		if node.Kind != yaml.MappingNode {
+			decoder.fail(node, path, "expected a mapping, got %s", kindName(node))
+			return
+		}
+		fields := structFields(value.Type())
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			index, ok := fields[keyNode.Value]
+			if !ok {
+				decoder.fail(keyNode, path, "unknown property %q", keyNode.Value)
+				continue
+			}
+			decoder.decode(valueNode, value.FieldByIndex(index), joinPath(path, keyNode.Value))
+			if field, ok := value.Addr().Interface().(*Field); ok {
+				// as in JSON, a zero bound written in the file is set
+				if keyNode.Value == "min_value" {
+					field.minValueSet = field.MinValue == 0
+				}
+				if keyNode.Value == "max_value" {
+					field.maxValueSet = field.MaxValue == 0
+				}
+			}
+		}
+		if _, ok := value.Addr().Interface().(*LogicJump); ok && !hasKey(node, "if") {
+			// a missing answer would silently mean false
+			decoder.fail(node, path, "missing property %q", "if")
+		}
+
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			decoder.fail(node, path, "expected a mapping, got %s", kindName(node))
+			return
+		}
+		value.Set(reflect.MakeMap(value.Type()))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			element := reflect.New(value.Type().Elem()).Elem()
+			decoder.decode(valueNode, element, joinPath(path, keyNode.Value))
+			value.SetMapIndex(reflect.ValueOf(keyNode.Value).Convert(value.Type().Key()), element)
+		}
+
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			decoder.fail(node, path, "expected a list, got %s", kindName(node))
+			return
+		}
+		slice := reflect.MakeSlice(value.Type(), len(node.Content), len(node.Content))
+		for i, elementNode := range node.Content {
+			decoder.decode(elementNode, slice.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+		value.Set(slice)
+
+	default:
+		if node.Kind != yaml.ScalarNode {
+			decoder.fail(node, path, "expected %s, got %s", scalarName(value.Kind()), kindName(node))
+			return
+		}
+		err := node.Decode(value.Addr().Interface())
+		if err != nil {
+			decoder.fail(node, path, "expected %s, got %q", scalarName(value.Kind()), node.Value)
+		}
+	}
+}
+
+// hasKey returns whether a mapping node has the key
+func hasKey(node *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+func scalarName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.String:
+		return "a string"
+	default:
+		return "a " + kind.String()
+	}
+}
+
+// DesignNames returns the names of the designs defined in the file, sorted
+func (definition *FormDefinition) DesignNames() []string {
+	names := make([]string, 0, len(definition.Designs))
+	for name := range definition.Designs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
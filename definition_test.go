@@ -0,0 +1,141 @@
+package typeform
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDefinitionYAML = `title: Customer survey
+tags: [customers]
+design: brand
+designs:
+  brand:
+    font: Source Sans Pro
+    colors: {question: "#3D3D3D", button: "#4FB0AE", answer: "#4FB0AE", background: "#FFFFFF"}
+fields:
+  - type: yes_no
+    ref: happy
+    question: Are you happy with us?
+    required: true
+  - type: multiple_choice
+    question: What could we do better?
+    choices: [Prices, {label: Support}]
+  - type: statement
+    ref: thanks
+    question: Thank you!
+logic_jumps:
+  - {from: happy, if: true, to: thanks}
+`
+
+func TestParseFormDefinition(t *testing.T) {
+	definition, err := ParseFormDefinition("survey.yaml", []byte(testDefinitionYAML))
+	assert.Nil(t, err, "no error should occur")
+
+	form := definition.Form
+	assert.Equal(t, "Customer survey", form.Title)
+	assert.Equal(t, []string{"customers"}, form.Tags)
+	assert.Equal(t, []Choice{{Label: "Prices"}, {Label: "Support"}}, form.Fields[1].Choices)
+	assert.Equal(t, []LogicJump{{From: "happy", To: "thanks", If: true}}, form.LogicJumps)
+	assert.Equal(t, "#3D3D3D", definition.Designs["brand"].Colors.Question)
+
+	resolved, err := definition.Resolve(map[string]string{"brand": "D3s1gn"})
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, "D3s1gn", resolved.DesignID)
+	_, err = definition.Resolve(map[string]string{})
+	assert.NotNil(t, err, "an unknown design should be reported")
+
+	// JSON is read the same way
+	data, _ := json.Marshal(form)
+	fromJSON, err := ParseFormDefinition("survey.json", data)
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, form, fromJSON.Form)
+}
+
+func TestParseFormDefinitionErrors(t *testing.T) {
+	definition := `title: Survey
+fields:
+  - type: short_text
+    question: Name?
+    max_characters: lots
+  - type: rating
+    question: Rate us
+    steps: 20
+    colour: red
+logic_jumps:
+  - {from: name, if: true, to: nowhere}
+`
+	_, err := ParseFormDefinition("survey.yaml", []byte(definition))
+	definitionErrors, ok := err.(DefinitionErrors)
+	assert.True(t, ok, "the errors should be DefinitionErrors")
+	assert.Equal(t, DefinitionErrors{
+		{File: "survey.yaml", Line: 5, Column: 21, Path: "fields[0].max_characters", Message: `expected an integer, got "lots"`},
+		{File: "survey.yaml", Line: 9, Column: 5, Path: "fields[1]", Message: `unknown property "colour"`},
+	}, definitionErrors, "decoding errors should be reported first")
+
+	definition = `title: Survey
+fields:
+  - type: rating
+    question: Rate us
+    steps: 20
+`
+	_, err = ParseFormDefinition("survey.yaml", []byte(definition))
+	assert.Equal(t, "survey.yaml:5:12: fields[0].steps: must be between 1 and 10, got 20", err.Error())
+
+	definition = `title: Survey
+design: brand
+designs:
+  other: {font: Arial}
+fields:
+  - type: yes_no
+    ref: happy
+    question: Happy?
+  - type: statement
+    ref: thanks
+    question: Thanks
+logic_jumps:
+  - {from: happy, to: thanks}
+`
+	_, err = ParseFormDefinition("survey.yaml", []byte(definition))
+	assert.Equal(t, "survey.yaml:13:5: logic_jumps[0]: missing property \"if\"\n"+
+		"survey.yaml:2:9: design: design \"brand\" is not defined in designs", err.Error())
+
+	_, err = ParseFormDefinition("survey.yaml", []byte("title: [unclosed"))
+	assert.NotNil(t, err, "syntax errors should be reported")
+}
+
+func TestFormDefinitionSchema(t *testing.T) {
+	var schema struct {
+		Properties  map[string]json.RawMessage `json:"properties"`
+		Definitions struct {
+			Field struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"field"`
+		} `json:"definitions"`
+	}
+	err := json.Unmarshal(FormDefinitionSchema, &schema)
+	assert.Nil(t, err, "the schema should be valid JSON")
+
+	keys := func(properties map[string]json.RawMessage) []string {
+		names := []string{}
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	names := func(value interface{}) []string {
+		fields := structFields(reflect.TypeOf(value))
+		names := []string{}
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	assert.Equal(t, names(definitionFile{}), keys(schema.Properties), "the schema should describe all the properties of a definition")
+	assert.Equal(t, names(Field{}), keys(schema.Definitions.Field.Properties), "the schema should describe all the properties of a field")
+}
@@ -0,0 +1,83 @@
+package typeform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ResponseError is the error returned when the API responds with a non-2xx status
+type ResponseError struct {
+	Method     string      // The method of the request
+	Path       string      // The path of the request
+	StatusCode int         // The HTTP status code of the response
+	Header     http.Header // The headers of the response
+	Body       []byte      // The raw (decompressed) body of the response
+	APIError   *APIError   // The decoded body of the response; nil if the body is not a JSON object
+}
+
+// maxErrorBodyLength is the maximum number of bytes of a raw body included in an error message
+const maxErrorBodyLength = 256
+
+func newResponseError(method string, path string, response *http.Response, responseBody []byte) *ResponseError {
+	responseError := &ResponseError{
+		Method:     method,
+		Path:       path,
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       responseBody,
+	}
+
+	var apiError APIError
+	if json.Unmarshal(responseBody, &apiError) == nil {
+		responseError.APIError = &apiError
+	}
+
+	return responseError
+}
+
+func (responseError *ResponseError) Error() string {
+	if responseError.APIError != nil {
+		return fmt.Sprintf("HTTPStatus %d: %s", responseError.StatusCode, responseError.APIError.String())
+	}
+
+	body := responseError.Body
+	if len(body) > maxErrorBodyLength {
+		body = body[:maxErrorBodyLength]
+	}
+	return fmt.Sprintf("HTTPStatus %d: %s %s: %q", responseError.StatusCode, responseError.Method, responseError.Path, body)
+}
+
+func hasStatus(err error, statusCodes ...int) bool {
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) {
+		return false
+	}
+	for _, statusCode := range statusCodes {
+		if responseError.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound tells whether err is a *ResponseError for a resource that does not exist
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized tells whether err is a *ResponseError for a missing or invalid API token
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsValidation tells whether err is a *ResponseError for a request the API considers invalid
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsRateLimited tells whether err is a *ResponseError for a request rejected because of too many requests
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
@@ -0,0 +1,57 @@
+package typeform
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newErrorServer(status int, contentType string, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestResponseError(t *testing.T) {
+	testServer := newErrorServer(http.StatusNotFound, "application/json", `{"error":"not_found","field":"id","description":"form not found"}`)
+	defer testServer.Close()
+
+	errorClient, err := NewClient(Latest, WithBaseURL(testServer.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	assert.Nil(t, err, "no error should occur")
+	errorClient.SetAPIToken("token")
+
+	_, err = errorClient.GetForm("missing")
+	assert.True(t, IsNotFound(fmt.Errorf("wrapped: %w", err)), "the error should be a not found error")
+	assert.False(t, IsValidation(err))
+
+	var responseError *ResponseError
+	assert.True(t, errors.As(err, &responseError))
+	assert.Equal(t, http.MethodGet, responseError.Method)
+	assert.Equal(t, "/latest/forms/missing", responseError.Path)
+	assert.Equal(t, "application/json", responseError.Header.Get("Content-Type"))
+	assert.Equal(t, &APIError{Error: "not_found", Field: "id", Description: "form not found"}, responseError.APIError)
+	assert.Equal(t, `HTTPStatus 404: Error: "not_found"; Field: "id"; Description: "form not found"`, err.Error())
+}
+
+func TestResponseErrorUnparseableBody(t *testing.T) {
+	testServer := newErrorServer(http.StatusBadGateway, "text/html", `<html>Bad Gateway</html>`)
+	defer testServer.Close()
+
+	errorClient, err := NewClient(Latest, WithBaseURL(testServer.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	assert.Nil(t, err, "no error should occur")
+	errorClient.SetAPIToken("token")
+
+	formInfo, err := errorClient.GetForm("abc")
+	assert.Nil(t, formInfo, "no form should be returned")
+
+	var responseError *ResponseError
+	assert.True(t, errors.As(err, &responseError), "an unparseable error body must not be a silent success")
+	assert.Nil(t, responseError.APIError)
+	assert.Equal(t, "<html>Bad Gateway</html>", string(responseError.Body))
+}
@@ -0,0 +1,706 @@
+package typeform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FieldSpec is implemented by the per-kind field types (ShortTextField, MultipleChoiceField, ...),
+// each carrying only the options that apply to its kind; their JSON
+// representation includes the "type" of the field
+type FieldSpec interface {
+	FieldType() FieldType
+	ToField() Field
+}
+
+// FieldCommon contains the options shared by fields of all kinds
+type FieldCommon struct {
+	Question    string   `json:"question"`              // Required, The main question text for the field
+	Description string   `json:"description,omitempty"` // The description (or sub-text) that appears below the main question text
+	Required    bool     `json:"required,omitempty"`    // Decides if the field is mandatory
+	Tags        []string `json:"tags,omitempty"`        // An array of tags as strings
+	Ref         string   `json:"ref,omitempty"`         // A unique reference for the field
+}
+
+func (common FieldCommon) toField(fieldType FieldType) Field {
+	return Field{
+		Type:        fieldType,
+		Question:    common.Question,
+		Description: common.Description,
+		Required:    common.Required,
+		Tags:        common.Tags,
+		Ref:         common.Ref,
+	}
+}
+
+func fieldCommon(field Field) FieldCommon {
+	return FieldCommon{
+		Question:    field.Question,
+		Description: field.Description,
+		Required:    field.Required,
+		Tags:        field.Tags,
+		Ref:         field.Ref,
+	}
+}
+
+// ShortTextField is a short_text field
+type ShortTextField struct {
+	FieldCommon
+	MaxCharacters *int `json:"max_characters,omitempty"`
+}
+
+// LongTextField is a long_text field
+type LongTextField struct {
+	FieldCommon
+	MaxCharacters *int `json:"max_characters,omitempty"`
+}
+
+// MultipleChoiceField is a multiple_choice field
+type MultipleChoiceField struct {
+	FieldCommon
+	Choices                 []Choice `json:"choices"`
+	AllowMultipleSelections bool     `json:"allow_multiple_selections"`
+	Randomize               bool     `json:"randomize"`
+	VerticalAlignment       bool     `json:"vertical_alignment"`
+	AddOtherChoice          bool     `json:"add_other_choice"`
+}
+
+// PictureChoiceField is a picture_choice field
+type PictureChoiceField struct {
+	FieldCommon
+	Choices                 []Choice `json:"choices"`
+	ShowLabels              bool     `json:"show_labels"`
+	Supersize               bool     `json:"supersize"`
+	AllowMultipleSelections bool     `json:"allow_multiple_selections"`
+	Randomize               bool     `json:"randomize"`
+	AddOtherChoice          bool     `json:"add_other_choice"`
+}
+
+// StatementField is a statement field
+type StatementField struct {
+	FieldCommon
+	ButtonText string `json:"button_text,omitempty"`
+	HideMarks  bool   `json:"hide_marks"`
+}
+
+// DropdownField is a dropdown field
+type DropdownField struct {
+	FieldCommon
+	Choices           []Choice `json:"choices"`
+	AlphabeticalOrder bool     `json:"alphabetical_order"`
+}
+
+// YesNoField is a yes_no field
+type YesNoField struct {
+	FieldCommon
+}
+
+// NumberField is a number field; unlike in Field, a zero MinValue or MaxValue is sent to the API
+type NumberField struct {
+	FieldCommon
+	MinValue *int `json:"min_value,omitempty"`
+	MaxValue *int `json:"max_value,omitempty"`
+}
+
+// RatingField is a rating field
+type RatingField struct {
+	FieldCommon
+	Steps int    `json:"steps,omitempty"`
+	Shape string `json:"shape,omitempty"`
+}
+
+// OpinionScaleField is an opinion_scale field
+type OpinionScaleField struct {
+	FieldCommon
+	Steps      int     `json:"steps,omitempty"` // 0 uses the default of the API
+	Labels     *Labels `json:"labels,omitempty"`
+	StartAtOne bool    `json:"start_at_one"`
+}
+
+// EmailField is an email field
+type EmailField struct {
+	FieldCommon
+}
+
+// WebsiteField is a website field
+type WebsiteField struct {
+	FieldCommon
+}
+
+// LegalField is a legal field
+type LegalField struct {
+	FieldCommon
+}
+
+// FieldType returns the type of the field
+func (ShortTextField) FieldType() FieldType { return ShortText }
+
+// FieldType returns the type of the field
+func (LongTextField) FieldType() FieldType { return LongText }
+
+// FieldType returns the type of the field
+func (MultipleChoiceField) FieldType() FieldType { return MultipleChoice }
+
+// FieldType returns the type of the field
+func (PictureChoiceField) FieldType() FieldType { return PictureChoice }
+
+// FieldType returns the type of the field
+func (StatementField) FieldType() FieldType { return Statement }
+
+// FieldType returns the type of the field
+func (DropdownField) FieldType() FieldType { return Dropdown }
+
+// FieldType returns the type of the field
+func (YesNoField) FieldType() FieldType { return YesNo }
+
+// FieldType returns the type of the field
+func (NumberField) FieldType() FieldType { return Number }
+
+// FieldType returns the type of the field
+func (RatingField) FieldType() FieldType { return Rating }
+
+// FieldType returns the type of the field
+func (OpinionScaleField) FieldType() FieldType { return OpinionScale }
+
+// FieldType returns the type of the field
+func (EmailField) FieldType() FieldType { return Email }
+
+// FieldType returns the type of the field
+func (WebsiteField) FieldType() FieldType { return Website }
+
+// FieldType returns the type of the field
+func (LegalField) FieldType() FieldType { return Legal }
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func intPointer(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
+
+// ToField converts the field to a Field
+func (spec ShortTextField) ToField() Field {
+	field := spec.toField(ShortText)
+	field.MaxCharacters = intValue(spec.MaxCharacters)
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec LongTextField) ToField() Field {
+	field := spec.toField(LongText)
+	field.MaxCharacters = intValue(spec.MaxCharacters)
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec MultipleChoiceField) ToField() Field {
+	field := spec.toField(MultipleChoice)
+	field.Choices = spec.Choices
+	field.AllowMultipleSelections = spec.AllowMultipleSelections
+	field.Randomize = spec.Randomize
+	field.VerticalAlignment = spec.VerticalAlignment
+	field.AddOtherChoice = spec.AddOtherChoice
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec PictureChoiceField) ToField() Field {
+	field := spec.toField(PictureChoice)
+	field.Choices = spec.Choices
+	field.ShowLabels = spec.ShowLabels
+	field.Supersize = spec.Supersize
+	field.AllowMultipleSelections = spec.AllowMultipleSelections
+	field.Randomize = spec.Randomize
+	field.AddOtherChoice = spec.AddOtherChoice
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec StatementField) ToField() Field {
+	field := spec.toField(Statement)
+	field.ButtonText = spec.ButtonText
+	field.HideMarks = spec.HideMarks
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec DropdownField) ToField() Field {
+	field := spec.toField(Dropdown)
+	field.Choices = spec.Choices
+	field.AlphabeticalOrder = spec.AlphabeticalOrder
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec YesNoField) ToField() Field {
+	return spec.toField(YesNo)
+}
+
+// ToField converts the field to a Field; an explicit zero MinValue or MaxValue is kept (see Field.Bounds)
+func (spec NumberField) ToField() Field {
+	field := spec.toField(Number)
+	field.MinValue, field.minValueSet = intValue(spec.MinValue), spec.MinValue != nil && *spec.MinValue == 0
+	field.MaxValue, field.maxValueSet = intValue(spec.MaxValue), spec.MaxValue != nil && *spec.MaxValue == 0
+	return field
+}
+
+// Bounds returns the minimum and maximum values of the answers to a number field, and whether they are set:
+// a zero MinValue or MaxValue is only a bound if it was set explicitly, by JSON, by the MinValue and MaxValue
+// options or by NumberField, as a zero Field.MinValue or Field.MaxValue is otherwise not sent to the API
+func (field Field) Bounds() (minValue int, hasMin bool, maxValue int, hasMax bool) {
+	return field.MinValue, field.MinValue != 0 || field.minValueSet, field.MaxValue, field.MaxValue != 0 || field.maxValueSet
+}
+
+// boundPointer returns a bound of a number field as set in a NumberField
+func boundPointer(value int, set bool) *int {
+	if !set {
+		return nil
+	}
+	return &value
+}
+
+// MarshalJSON marshals the field to JSON; unlike other zero options,
+// a zero MinValue or MaxValue set explicitly is included
+func (field Field) MarshalJSON() ([]byte, error) {
+	type plain Field
+	data, err := json.Marshal(plain(field))
+	if err != nil {
+		return nil, err
+	}
+	// the zero bounds were omitted, so they are appended
+	_, hasMin, _, hasMax := field.Bounds()
+	var extra []string
+	if hasMin && field.MinValue == 0 {
+		extra = append(extra, `"min_value":0`)
+	}
+	if hasMax && field.MaxValue == 0 {
+		extra = append(extra, `"max_value":0`)
+	}
+	if len(extra) == 0 {
+		return data, nil
+	}
+	return append(append(data[:len(data)-1], ","+strings.Join(extra, ",")...), '}'), nil
+}
+
+// UnmarshalJSON unmarshals a JSON field, recording whether its bounds are set (see Field.Bounds)
+func (field *Field) UnmarshalJSON(data []byte) error {
+	type plain Field
+	var bounds struct {
+		MinValue *int `json:"min_value"`
+		MaxValue *int `json:"max_value"`
+	}
+	err := json.Unmarshal(data, (*plain)(field))
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, &bounds)
+	if err != nil {
+		return err
+	}
+	field.minValueSet = bounds.MinValue != nil && *bounds.MinValue == 0
+	field.maxValueSet = bounds.MaxValue != nil && *bounds.MaxValue == 0
+	return nil
+}
+
+// ToField converts the field to a Field
+func (spec RatingField) ToField() Field {
+	field := spec.toField(Rating)
+	field.Steps = spec.Steps
+	field.Shape = spec.Shape
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec OpinionScaleField) ToField() Field {
+	field := spec.toField(OpinionScale)
+	field.Steps = spec.Steps
+	field.Labels = spec.Labels
+	field.StartAtOne = spec.StartAtOne
+	return field
+}
+
+// ToField converts the field to a Field
+func (spec EmailField) ToField() Field {
+	return spec.toField(Email)
+}
+
+// ToField converts the field to a Field
+func (spec WebsiteField) ToField() Field {
+	return spec.toField(Website)
+}
+
+// ToField converts the field to a Field
+func (spec LegalField) ToField() Field {
+	return spec.toField(Legal)
+}
+
+// Spec converts the field to the FieldSpec of its kind, dropping the options
+// that don't apply to it; zero numeric options are considered unset, except the bounds set explicitly (see Field.Bounds)
+func (field Field) Spec() (FieldSpec, error) {
+	common := fieldCommon(field)
+	switch field.Type {
+	case ShortText:
+		return ShortTextField{FieldCommon: common, MaxCharacters: intPointer(field.MaxCharacters)}, nil
+	case LongText:
+		return LongTextField{FieldCommon: common, MaxCharacters: intPointer(field.MaxCharacters)}, nil
+	case MultipleChoice:
+		return MultipleChoiceField{
+			FieldCommon:             common,
+			Choices:                 field.Choices,
+			AllowMultipleSelections: field.AllowMultipleSelections,
+			Randomize:               field.Randomize,
+			VerticalAlignment:       field.VerticalAlignment,
+			AddOtherChoice:          field.AddOtherChoice,
+		}, nil
+	case PictureChoice:
+		return PictureChoiceField{
+			FieldCommon:             common,
+			Choices:                 field.Choices,
+			ShowLabels:              field.ShowLabels,
+			Supersize:               field.Supersize,
+			AllowMultipleSelections: field.AllowMultipleSelections,
+			Randomize:               field.Randomize,
+			AddOtherChoice:          field.AddOtherChoice,
+		}, nil
+	case Statement:
+		return StatementField{FieldCommon: common, ButtonText: field.ButtonText, HideMarks: field.HideMarks}, nil
+	case Dropdown:
+		return DropdownField{FieldCommon: common, Choices: field.Choices, AlphabeticalOrder: field.AlphabeticalOrder}, nil
+	case YesNo:
+		return YesNoField{FieldCommon: common}, nil
+	case Number:
+		minValue, hasMin, maxValue, hasMax := field.Bounds()
+		return NumberField{FieldCommon: common, MinValue: boundPointer(minValue, hasMin), MaxValue: boundPointer(maxValue, hasMax)}, nil
+	case Rating:
+		return RatingField{FieldCommon: common, Steps: field.Steps, Shape: field.Shape}, nil
+	case OpinionScale:
+		return OpinionScaleField{FieldCommon: common, Steps: field.Steps, Labels: field.Labels, StartAtOne: field.StartAtOne}, nil
+	case Email:
+		return EmailField{FieldCommon: common}, nil
+	case Website:
+		return WebsiteField{FieldCommon: common}, nil
+	case Legal:
+		return LegalField{FieldCommon: common}, nil
+	}
+	return nil, fmt.Errorf("unknown field type: %q", field.Type)
+}
+
+// marshalFieldSpec marshals the provided value (a FieldSpec converted
+// to a type without MarshalJSON), adding the type of the field
+func marshalFieldSpec(fieldType FieldType, value interface{}) ([]byte, error) {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	encodedType, err := json.Marshal(fieldType)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(`{"type":`)
+	buffer.Write(encodedType)
+	if !bytes.Equal(encodedValue, []byte("{}")) {
+		buffer.WriteByte(',')
+		buffer.Write(encodedValue[1:])
+	} else {
+		buffer.WriteByte('}')
+	}
+	return buffer.Bytes(), nil
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec ShortTextField) MarshalJSON() ([]byte, error) {
+	type plain ShortTextField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec LongTextField) MarshalJSON() ([]byte, error) {
+	type plain LongTextField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec MultipleChoiceField) MarshalJSON() ([]byte, error) {
+	type plain MultipleChoiceField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec PictureChoiceField) MarshalJSON() ([]byte, error) {
+	type plain PictureChoiceField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec StatementField) MarshalJSON() ([]byte, error) {
+	type plain StatementField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec DropdownField) MarshalJSON() ([]byte, error) {
+	type plain DropdownField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec YesNoField) MarshalJSON() ([]byte, error) {
+	type plain YesNoField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec NumberField) MarshalJSON() ([]byte, error) {
+	type plain NumberField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec RatingField) MarshalJSON() ([]byte, error) {
+	type plain RatingField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec OpinionScaleField) MarshalJSON() ([]byte, error) {
+	type plain OpinionScaleField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec EmailField) MarshalJSON() ([]byte, error) {
+	type plain EmailField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec WebsiteField) MarshalJSON() ([]byte, error) {
+	type plain WebsiteField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// MarshalJSON marshals the field to JSON, including its type
+func (spec LegalField) MarshalJSON() ([]byte, error) {
+	type plain LegalField
+	return marshalFieldSpec(spec.FieldType(), plain(spec))
+}
+
+// UnmarshalFieldSpec unmarshals a JSON field into the FieldSpec of the kind named by its "type"
+func UnmarshalFieldSpec(data []byte) (FieldSpec, error) {
+	var header struct {
+		Type FieldType `json:"type"`
+	}
+	err := json.Unmarshal(data, &header)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec FieldSpec
+	switch header.Type {
+	case ShortText:
+		var field ShortTextField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case LongText:
+		var field LongTextField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case MultipleChoice:
+		var field MultipleChoiceField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case PictureChoice:
+		var field PictureChoiceField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case Statement:
+		var field StatementField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case Dropdown:
+		var field DropdownField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case YesNo:
+		var field YesNoField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case Number:
+		var field NumberField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case Rating:
+		var field RatingField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case OpinionScale:
+		var field OpinionScaleField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case Email:
+		var field EmailField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case Website:
+		var field WebsiteField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	case Legal:
+		var field LegalField
+		err = json.Unmarshal(data, &field)
+		spec = field
+	default:
+		return nil, fmt.Errorf("unknown field type: %q", header.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// FieldSpecs is a list of fields of different kinds; it unmarshals
+// each JSON field into the FieldSpec of its kind
+type FieldSpecs []FieldSpec
+
+// UnmarshalJSON unmarshals a JSON array of fields
+func (specs *FieldSpecs) UnmarshalJSON(data []byte) error {
+	var rawFields []json.RawMessage
+	err := json.Unmarshal(data, &rawFields)
+	if err != nil {
+		return err
+	}
+	if rawFields == nil {
+		*specs = nil
+		return nil
+	}
+
+	result := make(FieldSpecs, len(rawFields))
+	for i, rawField := range rawFields {
+		result[i], err = UnmarshalFieldSpec(rawField)
+		if err != nil {
+			return fmt.Errorf("fields[%d]: %s", i, err)
+		}
+	}
+	*specs = result
+	return nil
+}
+
+// ToFields converts the fields to Fields; a nil spec becomes a Field without a type, which Validate reports
+func (specs FieldSpecs) ToFields() []Field {
+	fields := make([]Field, len(specs))
+	for i, spec := range specs {
+		if spec != nil {
+			fields[i] = spec.ToField()
+		}
+	}
+	return fields
+}
+
+// SpecsFromFields converts the provided fields to the FieldSpecs of their kinds
+func SpecsFromFields(fields []Field) (FieldSpecs, error) {
+	specs := make(FieldSpecs, len(fields))
+	for i, field := range fields {
+		spec, err := field.Spec()
+		if err != nil {
+			return nil, fmt.Errorf("fields[%d]: %s", i, err)
+		}
+		specs[i] = spec
+	}
+	return specs, nil
+}
+
+// FieldSpecs converts the fields of the form to the FieldSpecs of their kinds;
+// if the form was decoded from JSON, each spec is decoded from the JSON of its field
+func (formInfo FormInfo) FieldSpecs() (FieldSpecs, error) {
+	if formInfo.rawFields == nil {
+		return SpecsFromFields(formInfo.Fields)
+	}
+	var specs FieldSpecs
+	err := json.Unmarshal(formInfo.rawFields, &specs)
+	if err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// UnmarshalJSON unmarshals a FormInfo JSON, keeping the JSON of its fields for FieldSpecs
+func (formInfo *FormInfo) UnmarshalJSON(data []byte) error {
+	type plain FormInfo
+	var raw struct {
+		Fields json.RawMessage `json:"fields"`
+	}
+	err := json.Unmarshal(data, (*plain)(formInfo))
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	formInfo.rawFields = nil
+	if len(raw.Fields) > 0 && string(raw.Fields) != "null" {
+		formInfo.rawFields = raw.Fields
+	}
+	return nil
+}
+
+// TypedForm is like Form, but its fields are FieldSpecs;
+// create it with CreateTypedForm
+type TypedForm struct {
+	Title            string      `json:"title"`
+	Fields           FieldSpecs  `json:"fields"`
+	Tags             []string    `json:"tags,omitempty"`
+	DesignID         string      `json:"design_id,omitempty"`
+	WebhookSubmitURL string      `json:"webhook_submit_url,omitempty"`
+	URLIDs           []string    `json:"url_ids,omitempty"`
+	Branding         bool        `json:"branding,omitempty"`
+	LogicJumps       []LogicJump `json:"logic_jumps,omitempty"`
+}
+
+// TypedForm converts the form to a TypedForm
+func (form Form) TypedForm() (TypedForm, error) {
+	specs, err := SpecsFromFields(form.Fields)
+	if err != nil {
+		return TypedForm{}, err
+	}
+	return TypedForm{
+		Title:            form.Title,
+		Fields:           specs,
+		Tags:             form.Tags,
+		DesignID:         form.DesignID,
+		WebhookSubmitURL: form.WebhookSubmitURL,
+		URLIDs:           form.URLIDs,
+		Branding:         form.Branding,
+		LogicJumps:       form.LogicJumps,
+	}, nil
+}
+
+// ToForm converts the form to a Form
+func (typedForm TypedForm) ToForm() Form {
+	return Form{
+		Title:            typedForm.Title,
+		Fields:           typedForm.Fields.ToFields(),
+		Tags:             typedForm.Tags,
+		DesignID:         typedForm.DesignID,
+		WebhookSubmitURL: typedForm.WebhookSubmitURL,
+		URLIDs:           typedForm.URLIDs,
+		Branding:         typedForm.Branding,
+		LogicJumps:       typedForm.LogicJumps,
+	}
+}
+
+// Validate validates the form like Form.Validate; explicit zero bounds are kept by ToForm, so they are checked too
+func (typedForm TypedForm) Validate() error {
+	return typedForm.ToForm().Validate()
+}
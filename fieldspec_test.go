@@ -0,0 +1,106 @@
+package typeform
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldSpecMarshalJSON(t *testing.T) {
+	zero := 0
+	encoded, err := json.Marshal(FieldSpecs{
+		NumberField{FieldCommon: FieldCommon{Question: "How many?"}, MinValue: &zero},
+		YesNoField{},
+	})
+	assert.Nil(t, err, "no error should occur")
+	assert.JSONEq(t, `[
+		{"type":"number","question":"How many?","min_value":0},
+		{"type":"yes_no","question":""}
+	]`, string(encoded))
+}
+
+func TestFieldSpecsUnmarshalJSON(t *testing.T) {
+	var specs FieldSpecs
+	err := json.Unmarshal([]byte(`[
+		{"type":"short_text","question":"Name?","max_characters":10,"ref":"name"},
+		{"type":"dropdown","question":"Where?","choices":[{"label":"Europe"}],"max_characters":10},
+		{"type":"number","question":"How many?","min_value":0,"max_value":5}
+	]`), &specs)
+	assert.Nil(t, err, "no error should occur")
+
+	maxCharacters, zero, five := 10, 0, 5
+	assert.Equal(t, FieldSpecs{
+		ShortTextField{FieldCommon: FieldCommon{Question: "Name?", Ref: "name"}, MaxCharacters: &maxCharacters},
+		DropdownField{FieldCommon: FieldCommon{Question: "Where?"}, Choices: []Choice{{Label: "Europe"}}},
+		NumberField{FieldCommon: FieldCommon{Question: "How many?"}, MinValue: &zero, MaxValue: &five},
+	}, specs)
+
+	err = json.Unmarshal([]byte(`[{"type":"hologram","question":"?"}]`), &specs)
+	assert.NotNil(t, err, "unknown types should be rejected")
+}
+
+func TestFieldSpecConversion(t *testing.T) {
+	field := Field{
+		Type:          Dropdown,
+		Question:      "Where?",
+		Ref:           "where",
+		MaxCharacters: 3, // does not apply to dropdowns
+		Choices:       []Choice{{Label: "Europe"}},
+	}
+
+	spec, err := field.Spec()
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, DropdownField{FieldCommon: FieldCommon{Question: "Where?", Ref: "where"}, Choices: []Choice{{Label: "Europe"}}}, spec)
+
+	field.MaxCharacters = 0
+	assert.Equal(t, field, spec.ToField())
+
+	typedForm, err := Form{Title: "Typed", Fields: []Field{field}}.TypedForm()
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, Form{Title: "Typed", Fields: []Field{field}}, typedForm.ToForm())
+}
+
+func TestFieldExplicitZeroBounds(t *testing.T) {
+	var formInfo FormInfo
+	err := json.Unmarshal([]byte(`{"id":"abc","fields":[{"type":"number","question":"How many?","min_value":0}]}`), &formInfo)
+	assert.Nil(t, err, "no error should occur")
+
+	specs, err := formInfo.FieldSpecs()
+	assert.Nil(t, err, "no error should occur")
+	zero := 0
+	assert.Equal(t, FieldSpecs{NumberField{FieldCommon: FieldCommon{Question: "How many?"}, MinValue: &zero}}, specs, "the explicit zero should be kept")
+
+	minValue, hasMin, _, hasMax := formInfo.Fields[0].Bounds()
+	assert.Equal(t, 0, minValue)
+	assert.True(t, hasMin, "the explicit zero should be a bound")
+	assert.False(t, hasMax)
+	encoded, err := json.Marshal(formInfo.Fields[0])
+	assert.Nil(t, err, "no error should occur")
+	assert.JSONEq(t, `{"type":"number","question":"How many?","min_value":0}`, string(encoded))
+
+	_, hasMin, _, _ = Field{Type: Number, Question: "How many?"}.Bounds()
+	assert.False(t, hasMin, "a zero MinValue should not be a bound unless set explicitly")
+	_, _, _, hasMax = NewForm("Bounds").Number("How many?", MaxValue(0)).form.Fields[0].Bounds()
+	assert.True(t, hasMax, "the MaxValue option should set the bound, even if zero")
+}
+
+func TestTypedFormValidate(t *testing.T) {
+	one, zero := 1, 0
+	typedForm := TypedForm{
+		Title: "Typed",
+		Fields: FieldSpecs{
+			NumberField{FieldCommon: FieldCommon{Question: "How many?"}, MinValue: &one, MaxValue: &zero},
+			nil,
+		},
+	}
+	assert.NotPanics(t, func() { typedForm.ToForm() }, "nil specs should not panic")
+	err := typedForm.Validate()
+	assert.NotNil(t, err, "the form should be invalid")
+	paths := map[string]bool{}
+	for _, violation := range err.(ValidationErrors) {
+		paths[violation.Path] = true
+	}
+	assert.True(t, paths["fields[0].min_value"], "a minimum greater than an explicit zero maximum should be reported")
+	assert.True(t, paths["fields[1].type"], "a nil spec should be reported")
+}
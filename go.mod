@@ -0,0 +1,8 @@
+module github.com/gagliardetto/go-ask-awesomely
+
+go 1.16
+
+require (
+	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,173 @@
+package typeform
+
+import (
+	"context"
+)
+
+// DefaultResponsesPageSize is the number of responses fetched per request by ResponsesIter
+const DefaultResponsesPageSize = 100
+
+// MaxResponsesPageSize is the maximum number of responses the API returns per request
+const MaxResponsesPageSize = 1000
+
+// ResponsesIterOptions configures a ResponseIterator
+type ResponsesIterOptions struct {
+	Query    ResponsesQuery // The filters and the order of the responses; its Limit is replaced by PageSize
+	PageSize int            // The number of responses fetched per request, up to MaxResponsesPageSize; 0 uses DefaultResponsesPageSize
+	Prefetch bool           // If true, the next page is fetched in the background while the current one is consumed
+}
+
+// ResponseIterator walks all the responses matching a query, fetching them one page at a time
+//
+//	iterator := client.ResponsesIter(ctx, formID, typeform.ResponsesIterOptions{Prefetch: true})
+//	defer iterator.Close()
+//	for iterator.Next() {
+//		response := iterator.Response()
+//		...
+//	}
+//	if err := iterator.Err(); err != nil {
+//		...
+//	}
+type ResponseIterator struct {
+	client  *Client
+	ctx     context.Context
+	cancel  context.CancelFunc
+	formID  string
+	query   ResponsesQuery
+	pages   chan responsesPage // only used when prefetching
+	current []Response
+	index   int
+	last    bool // true once the last page has been fetched
+	err     error
+}
+
+type responsesPage struct {
+	items []Response
+	last  bool
+	err   error
+}
+
+// ResponsesIter returns an iterator over all the responses to a form matching opts.Query;
+// the iterator must be closed when not consumed until the end
+func (client *Client) ResponsesIter(ctx context.Context, formID string, opts ResponsesIterOptions) *ResponseIterator {
+	ctx, cancel := context.WithCancel(ctx)
+
+	query := opts.Query
+	query.Limit = opts.PageSize
+	if query.Limit <= 0 {
+		query.Limit = DefaultResponsesPageSize
+	}
+	if query.Limit > MaxResponsesPageSize {
+		query.Limit = MaxResponsesPageSize
+	}
+
+	iterator := &ResponseIterator{
+		client: client,
+		ctx:    ctx,
+		cancel: cancel,
+		formID: formID,
+		query:  query,
+	}
+
+	if opts.Prefetch {
+		iterator.pages = make(chan responsesPage, 1)
+		go iterator.prefetch()
+	}
+
+	return iterator
+}
+
+// fetchPage fetches the page at the current offset, and moves the offset to the next page
+func (iterator *ResponseIterator) fetchPage() responsesPage {
+	responses, err := iterator.client.GetResponsesWithContext(iterator.ctx, iterator.formID, iterator.query)
+	if err != nil {
+		return responsesPage{err: err}
+	}
+
+	iterator.query.Offset += len(responses.Items)
+
+	// the API can return shorter pages than asked, so a short page only ends
+	// the iteration when the total number of responses is unknown
+	last := iterator.query.Offset >= responses.TotalItems
+	if responses.TotalItems == 0 {
+		last = len(responses.Items) < iterator.query.Limit
+	}
+	return responsesPage{
+		items: responses.Items,
+		last:  last,
+	}
+}
+
+// prefetch fetches the pages in the background, one page ahead of the consumer
+func (iterator *ResponseIterator) prefetch() {
+	defer close(iterator.pages)
+	for {
+		page := iterator.fetchPage()
+		select {
+		case iterator.pages <- page:
+		case <-iterator.ctx.Done():
+			return
+		}
+		if page.last || page.err != nil {
+			return
+		}
+	}
+}
+
+func (iterator *ResponseIterator) nextPage() responsesPage {
+	if iterator.pages == nil {
+		return iterator.fetchPage()
+	}
+	select {
+	case page, ok := <-iterator.pages:
+		if !ok {
+			return responsesPage{err: iterator.ctx.Err(), last: true}
+		}
+		return page
+	case <-iterator.ctx.Done():
+		return responsesPage{err: iterator.ctx.Err()}
+	}
+}
+
+// Next advances the iterator to the next response, fetching the next page if needed;
+// it returns false when there are no more responses or an error occurred
+func (iterator *ResponseIterator) Next() bool {
+	for {
+		if iterator.err != nil {
+			return false
+		}
+		if iterator.index < len(iterator.current) {
+			iterator.index++
+			return true
+		}
+		if iterator.last {
+			iterator.cancel()
+			return false
+		}
+
+		page := iterator.nextPage()
+		if page.err != nil {
+			iterator.err = page.err
+			iterator.cancel()
+			return false
+		}
+		iterator.current = page.items
+		iterator.index = 0
+		iterator.last = page.last || len(page.items) == 0
+	}
+}
+
+// Response returns the current response; it must be called after a call to Next that returned true
+func (iterator *ResponseIterator) Response() Response {
+	return iterator.current[iterator.index-1]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (iterator *ResponseIterator) Err() error {
+	return iterator.err
+}
+
+// Close stops the iteration, and the fetching of pages in the background
+func (iterator *ResponseIterator) Close() {
+	iterator.cancel()
+}
@@ -0,0 +1,103 @@
+package typeform
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPagingServer(total int, failAtOffset int) (*httptest.Server, *int32) {
+	var requests int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if offset == failAtOffset {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"boom"}`))
+			return
+		}
+
+		responses := Responses{TotalItems: total, PageCount: (total + limit - 1) / limit, Items: []Response{}}
+		for i := offset; i < total && i < offset+limit; i++ {
+			responses.Items = append(responses.Items, Response{Token: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	return testServer, &requests
+}
+
+func TestResponsesIter(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		testServer, requests := newPagingServer(250, -1)
+
+		iteratorClient, err := NewClient(Latest, WithBaseURL(testServer.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+		assert.Nil(t, err, "no error should occur")
+		iteratorClient.SetAPIToken("token")
+
+		iterator := iteratorClient.ResponsesIter(context.Background(), "abc", ResponsesIterOptions{PageSize: 100, Prefetch: prefetch})
+		count := 0
+		for iterator.Next() {
+			assert.Equal(t, strconv.Itoa(count), iterator.Response().Token, "responses should be returned in order")
+			count++
+		}
+		iterator.Close()
+
+		assert.Nil(t, iterator.Err(), "no error should occur")
+		assert.Equal(t, 250, count)
+		assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+		testServer.Close()
+	}
+}
+
+func TestResponsesIterError(t *testing.T) {
+	testServer, _ := newPagingServer(250, 100)
+	defer testServer.Close()
+
+	iteratorClient, err := NewClient(Latest, WithBaseURL(testServer.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	assert.Nil(t, err, "no error should occur")
+	iteratorClient.SetAPIToken("token")
+
+	iterator := iteratorClient.ResponsesIter(context.Background(), "abc", ResponsesIterOptions{PageSize: 100, Prefetch: true})
+	defer iterator.Close()
+
+	count := 0
+	for iterator.Next() {
+		count++
+	}
+	assert.Equal(t, 100, count, "the responses of the first page should be returned")
+	assert.NotNil(t, iterator.Err(), "the error of the second page should be returned")
+}
+
+func TestResponsesIterShortPages(t *testing.T) {
+	// the server returns at most 40 responses per page, whatever the limit
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		responses := Responses{TotalItems: 100, Items: []Response{}}
+		for i := offset; i < 100 && i < offset+40; i++ {
+			responses.Items = append(responses.Items, Response{Token: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer testServer.Close()
+
+	iteratorClient, err := NewClient(Latest, WithBaseURL(testServer.URL))
+	assert.Nil(t, err, "no error should occur")
+	iteratorClient.SetAPIToken("token")
+
+	iterator := iteratorClient.ResponsesIter(context.Background(), "abc", ResponsesIterOptions{PageSize: 5000})
+	defer iterator.Close()
+	assert.Equal(t, MaxResponsesPageSize, iterator.query.Limit, "the page size should be capped")
+	count := 0
+	for iterator.Next() {
+		count++
+	}
+	assert.Nil(t, iterator.Err(), "no error should occur")
+	assert.Equal(t, 100, count, "short pages should not end the iteration")
+}
@@ -0,0 +1,226 @@
+package typeform
+
+import (
+	"fmt"
+)
+
+// LogicIssueKind is the kind of a problem found in the logic jumps of a form
+type LogicIssueKind string
+
+const (
+	// UnknownRef : the jump references a Ref that no field has.
+	UnknownRef LogicIssueKind = "unknown_ref"
+
+	// NonBooleanSource : the jump starts from a field whose answer is not a yes or a no,
+	// so its If condition can't be evaluated.
+	NonBooleanSource LogicIssueKind = "non_boolean_source"
+
+	// CyclicJump : the jump goes back to a field from which the respondent can reach the jump again.
+	CyclicJump LogicIssueKind = "cyclic_jump"
+
+	// UnreachableField : no path through the form reaches the field.
+	UnreachableField LogicIssueKind = "unreachable_field"
+
+	// ConflictingJump : another jump starts from the same field, with the same condition, to a different field.
+	ConflictingJump LogicIssueKind = "conflicting_jump"
+)
+
+// LogicIssue is a problem found in the logic jumps of a form
+type LogicIssue struct {
+	Kind    LogicIssueKind
+	Path    string // The path of the offending part, e.g. "logic_jumps[1]" or "fields[3]"
+	Message string
+}
+
+func (issue LogicIssue) String() string {
+	return fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+}
+
+// IsBoolean tells whether the answers to fields of this type are either yes or no
+func (fieldType FieldType) IsBoolean() bool {
+	return fieldType == YesNo || fieldType == Legal
+}
+
+// logicGraph is the graph of the questions of a form: an edge goes from
+// each field to each of the fields a respondent can see next
+type logicGraph struct {
+	form  Form
+	refs  map[string]int // field index by Ref
+	edges [][]int
+}
+
+func newLogicGraph(form Form) *logicGraph {
+	graph := &logicGraph{
+		form:  form,
+		refs:  fieldRefs(form),
+		edges: make([][]int, len(form.Fields)),
+	}
+
+	// the outcomes (yes and no) of each field that are covered by a jump
+	covered := make([]map[bool]bool, len(form.Fields))
+	for _, jump := range form.LogicJumps {
+		from, fromOK := graph.refs[jump.From]
+		to, toOK := graph.refs[jump.To]
+		if !fromOK || !toOK {
+			continue
+		}
+		graph.addEdge(from, to)
+		if form.Fields[from].Type.IsBoolean() {
+			if covered[from] == nil {
+				covered[from] = map[bool]bool{}
+			}
+			covered[from][jump.If] = true
+		}
+	}
+
+	// unless all the outcomes jump elsewhere, a field is followed by the next one
+	for i := 0; i+1 < len(form.Fields); i++ {
+		if len(covered[i]) == 2 {
+			continue
+		}
+		graph.addEdge(i, i+1)
+	}
+
+	return graph
+}
+
+// fieldRefs returns the index of the fields by Ref; if several fields have the same Ref, the first one is used
+func fieldRefs(form Form) map[string]int {
+	refs := map[string]int{}
+	for i, field := range form.Fields {
+		if _, ok := refs[field.Ref]; field.Ref != "" && !ok {
+			refs[field.Ref] = i
+		}
+	}
+	return refs
+}
+
+func (graph *logicGraph) addEdge(from, to int) {
+	for _, existing := range graph.edges[from] {
+		if existing == to {
+			return
+		}
+	}
+	graph.edges[from] = append(graph.edges[from], to)
+}
+
+// reachable returns the fields that can be reached from the start field (included)
+func (graph *logicGraph) reachable(start int) []bool {
+	seen := make([]bool, len(graph.edges))
+	if start < 0 || start >= len(graph.edges) {
+		return seen
+	}
+	queue := []int{start}
+	seen[start] = true
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range graph.edges[current] {
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return seen
+}
+
+// AnalyzeLogic checks the logic jumps of the form, and returns the problems found:
+// jumps referencing unknown refs, jumps from fields that aren't yes/no questions,
+// jumps creating cycles, conflicting jumps and fields that can't be reached
+func (form Form) AnalyzeLogic() []LogicIssue {
+	var issues []LogicIssue
+	report := func(kind LogicIssueKind, path string, format string, args ...interface{}) {
+		issues = append(issues, LogicIssue{
+			Kind:    kind,
+			Path:    path,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	graph := newLogicGraph(form)
+
+	type source struct {
+		from   string
+		answer bool
+	}
+	targets := map[source]int{} // index of the first jump for each source and answer
+
+	for i, jump := range form.LogicJumps {
+		path := fmt.Sprintf("logic_jumps[%d]", i)
+
+		from, fromOK := graph.refs[jump.From]
+		to, toOK := graph.refs[jump.To]
+		if !fromOK {
+			report(UnknownRef, path+".from", "no field has ref %q", jump.From)
+		}
+		if !toOK {
+			report(UnknownRef, path+".to", "no field has ref %q", jump.To)
+		}
+		if !fromOK || !toOK {
+			continue
+		}
+
+		if !form.Fields[from].Type.IsBoolean() {
+			report(NonBooleanSource, path+".from", "field %q is a %s field, whose answer can't be compared with %v", jump.From, form.Fields[from].Type, jump.If)
+		}
+
+		key := source{from: jump.From, answer: jump.If}
+		if first, ok := targets[key]; ok {
+			if form.LogicJumps[first].To != jump.To {
+				report(ConflictingJump, path, "logic_jumps[%d] already jumps from %q to %q when the answer is %v", first, jump.From, form.LogicJumps[first].To, jump.If)
+			}
+		} else {
+			targets[key] = i
+		}
+
+		if to <= from && graph.reachable(to)[from] {
+			report(CyclicJump, path, "jumping back from %q to %q creates a cycle", jump.From, jump.To)
+		}
+	}
+
+	reached := graph.reachable(0)
+	for i, field := range form.Fields {
+		if reached[i] {
+			continue
+		}
+		path := fmt.Sprintf("fields[%d]", i)
+		if field.Ref != "" {
+			report(UnreachableField, path, "field %q can't be reached", field.Ref)
+		} else {
+			report(UnreachableField, path, "field can't be reached")
+		}
+	}
+
+	return issues
+}
+
+// NextField returns the index of the field shown after the field at index current, given the answers so far
+// keyed by the Ref of their field (as returned by Response.AnswersByRef); it returns len(form.Fields)
+// when the form is over. The first logic jump from the current field matching its answer is followed;
+// jumps to unknown refs are ignored, and unanswered fields are followed by the next field.
+func (form Form) NextField(current int, answers map[string]Answer) int {
+	if current < 0 || current >= len(form.Fields) {
+		return len(form.Fields)
+	}
+	field := form.Fields[current]
+	answer, ok := answers[field.Ref]
+	if field.Ref == "" || !ok || answer.Boolean == nil {
+		return current + 1
+	}
+	return form.nextFieldIf(current, *answer.Boolean, fieldRefs(form))
+}
+
+// nextFieldIf returns the index of the field shown after the field at index current when its answer is yes (or no)
+func (form Form) nextFieldIf(current int, yes bool, refs map[string]int) int {
+	from := form.Fields[current].Ref
+	for _, jump := range form.LogicJumps {
+		if jump.From != from || jump.If != yes {
+			continue
+		}
+		if to, ok := refs[jump.To]; ok {
+			return to
+		}
+	}
+	return current + 1
+}
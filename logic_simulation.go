@@ -0,0 +1,235 @@
+package typeform
+
+// MaxSimulatedPaths is the maximum number of paths enumerated by SimulateLogic
+const MaxSimulatedPaths = 10000
+
+// LogicPath is a path of a respondent through a form
+type LogicPath struct {
+	Fields      []int           // The indexes of the fields seen, in order
+	Answers     map[string]bool // The answers to the yes/no questions leading to this path, by Ref of their field
+	Questions   int             // The number of questions seen; statements are not counted
+	Probability float64         // The probability of the path, if every yes/no question is answered yes half of the time
+	Cyclic      bool            // Whether the path jumps back to a field already seen; it's cut there
+}
+
+// LogicSimulation is the result of SimulateLogic. The figures cover every path, even when Paths is truncated,
+// unless the form also has cycles: they are then left zero, and Reach is nil.
+type LogicSimulation struct {
+	Paths             []LogicPath
+	MinQuestions      int       // The minimum number of questions seen by a respondent
+	MaxQuestions      int       // The maximum number of questions seen by a respondent
+	ExpectedQuestions float64   // The average number of questions seen, weighted by the probability of the paths
+	Reach             []float64 // The probability of each field to be seen, by index
+	Truncated         bool      // Whether the form has more than MaxSimulatedPaths paths; only the first ones are enumerated
+}
+
+// RareFields returns the indexes of the fields that can be seen, but with a probability below threshold
+// (e.g. 0.1 for the fields seen by less than 10% of the respondents)
+func (simulation LogicSimulation) RareFields(threshold float64) []int {
+	var rare []int
+	for i, reach := range simulation.Reach {
+		if reach > 0 && reach < threshold {
+			rare = append(rare, i)
+		}
+	}
+	return rare
+}
+
+// SimulateLogic enumerates every path through the form, answering yes and no to each
+// yes/no question whose answer changes the next field, and following the logic jumps as NextField does.
+// Unanswered questions are not simulated, and cyclic paths are cut when they jump back to a field already seen.
+func (form Form) SimulateLogic() LogicSimulation {
+	simulator := &logicSimulator{form: form, refs: fieldRefs(form)}
+	simulation := LogicSimulation{}
+	if len(form.Fields) > 0 {
+		simulator.walk(0, nil, nil, 1)
+	}
+	simulation.Paths = simulator.paths
+	simulation.Truncated = simulator.truncated
+
+	// without cycles, the figures are computed field by field, so they don't depend on the enumerated paths;
+	// with cycles, they can only be computed from the paths, if they are all enumerated
+	if order, acyclic := simulator.order(); acyclic {
+		simulator.propagate(&simulation, order)
+	} else if !simulation.Truncated {
+		simulator.summarizePaths(&simulation)
+	}
+	return simulation
+}
+
+// summarizePaths computes the figures of the simulation from its paths
+func (simulator *logicSimulator) summarizePaths(simulation *LogicSimulation) {
+	simulation.Reach = make([]float64, len(simulator.form.Fields))
+	for i, path := range simulation.Paths {
+		if i == 0 || path.Questions < simulation.MinQuestions {
+			simulation.MinQuestions = path.Questions
+		}
+		if path.Questions > simulation.MaxQuestions {
+			simulation.MaxQuestions = path.Questions
+		}
+		simulation.ExpectedQuestions += path.Probability * float64(path.Questions)
+		for _, field := range path.Fields {
+			simulation.Reach[field] += path.Probability
+		}
+	}
+}
+
+// logicSimulator is the state of SimulateLogic
+type logicSimulator struct {
+	form      Form
+	refs      map[string]int
+	paths     []LogicPath
+	truncated bool
+}
+
+// branch is the answer to a yes/no question taken by a path
+type branch struct {
+	ref string
+	yes bool
+}
+
+// walk follows the path from the field at index current; fields and branches are the path so far
+func (simulator *logicSimulator) walk(current int, fields []int, branches []branch, probability float64) {
+	if simulator.truncated {
+		return
+	}
+	form := simulator.form
+	for current < len(form.Fields) {
+		for _, seen := range fields {
+			if seen == current {
+				simulator.addPath(fields, branches, probability, true)
+				return
+			}
+		}
+		fields = append(fields, current)
+
+		next := simulator.successors(current)
+		if len(next) == 2 {
+			// each branch gets its own copy of the slices, as both append to them
+			ref := form.Fields[current].Ref
+			simulator.walk(next[0], append([]int(nil), fields...), append(append([]branch(nil), branches...), branch{ref, true}), probability/2)
+			simulator.walk(next[1], append([]int(nil), fields...), append(append([]branch(nil), branches...), branch{ref, false}), probability/2)
+			return
+		}
+		current = next[0]
+	}
+	simulator.addPath(fields, branches, probability, false)
+}
+
+func (simulator *logicSimulator) addPath(fields []int, branches []branch, probability float64, cyclic bool) {
+	if len(simulator.paths) >= MaxSimulatedPaths {
+		simulator.truncated = true
+		return
+	}
+	path := LogicPath{
+		Fields:      append([]int(nil), fields...),
+		Answers:     map[string]bool{},
+		Probability: probability,
+		Cyclic:      cyclic,
+	}
+	for _, branch := range branches {
+		path.Answers[branch.ref] = branch.yes
+	}
+	for _, field := range fields {
+		if simulator.form.Fields[field].Type != Statement {
+			path.Questions++
+		}
+	}
+	simulator.paths = append(simulator.paths, path)
+}
+
+// successors returns the indexes of the fields that can follow the field at index current:
+// the fields after a yes and after a no if they differ, or else the only next field;
+// the index len(form.Fields) is the end of the form
+func (simulator *logicSimulator) successors(current int) []int {
+	form := simulator.form
+	field := form.Fields[current]
+	if field.Ref != "" && field.Type.IsBoolean() {
+		yes := form.nextFieldIf(current, true, simulator.refs)
+		no := form.nextFieldIf(current, false, simulator.refs)
+		if yes != no {
+			return []int{yes, no}
+		}
+		return []int{yes}
+	}
+	return []int{current + 1}
+}
+
+// order returns the fields that can be seen, each before the fields that can follow it;
+// acyclic is false if a field can be seen again after itself
+func (simulator *logicSimulator) order() (order []int, acyclic bool) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(simulator.form.Fields))
+	acyclic = true
+	var visit func(current int)
+	visit = func(current int) {
+		states[current] = visiting
+		for _, next := range simulator.successors(current) {
+			switch {
+			case next >= len(states):
+			case states[next] == visiting:
+				acyclic = false
+			case states[next] == unvisited:
+				visit(next)
+			}
+		}
+		states[current] = visited
+		order = append(order, current)
+	}
+	if len(states) > 0 {
+		visit(0)
+	}
+
+	// the fields were appended after the fields following them
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, acyclic
+}
+
+// propagate computes the figures of the simulation of a form without cycles, following the fields in order:
+// the probability of a field to be seen is split between the fields that can follow it
+func (simulator *logicSimulator) propagate(simulation *LogicSimulation, order []int) {
+	fields := simulator.form.Fields
+	simulation.Reach = make([]float64, len(fields))
+	if len(fields) == 0 {
+		return
+	}
+	simulation.Reach[0] = 1
+	for _, current := range order {
+		next := simulator.successors(current)
+		for _, field := range next {
+			if field < len(fields) {
+				simulation.Reach[field] += simulation.Reach[current] / float64(len(next))
+			}
+		}
+		if fields[current].Type != Statement {
+			simulation.ExpectedQuestions += simulation.Reach[current]
+		}
+	}
+
+	// the fewest and most questions seen from each field to the end, in reverse order
+	minQuestions := make([]int, len(fields)+1)
+	maxQuestions := make([]int, len(fields)+1)
+	for i := len(order) - 1; i >= 0; i-- {
+		current := order[i]
+		for j, field := range simulator.successors(current) {
+			if j == 0 || minQuestions[field] < minQuestions[current] {
+				minQuestions[current] = minQuestions[field]
+			}
+			if j == 0 || maxQuestions[field] > maxQuestions[current] {
+				maxQuestions[current] = maxQuestions[field]
+			}
+		}
+		if fields[current].Type != Statement {
+			minQuestions[current]++
+			maxQuestions[current]++
+		}
+	}
+	simulation.MinQuestions = minQuestions[0]
+	simulation.MaxQuestions = maxQuestions[0]
+}
@@ -0,0 +1,178 @@
+package typeform
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func issueKinds(issues []LogicIssue) map[string]LogicIssueKind {
+	kinds := map[string]LogicIssueKind{}
+	for _, issue := range issues {
+		kinds[issue.Path] = issue.Kind
+	}
+	return kinds
+}
+
+func TestAnalyzeLogicValid(t *testing.T) {
+	form := Form{
+		Title: "Branching",
+		Fields: []Field{
+			{Type: YesNo, Question: "Jump?", Ref: "jump"},
+			{Type: ShortText, Question: "Skipped on yes", Ref: "skipped"},
+			{Type: Rating, Question: "Rate", Ref: "rate", Steps: 5},
+		},
+		LogicJumps: []LogicJump{{From: "jump", To: "rate", If: true}},
+	}
+	assert.Empty(t, form.AnalyzeLogic())
+}
+
+func TestAnalyzeLogicIssues(t *testing.T) {
+	form := Form{
+		Title: "Broken",
+		Fields: []Field{
+			{Type: YesNo, Question: "Start", Ref: "start"},
+			{Type: ShortText, Question: "Never seen", Ref: "never"},
+			{Type: YesNo, Question: "Loop?", Ref: "loop"},
+			{Type: ShortText, Question: "Text", Ref: "text"},
+			{Type: Legal, Question: "Terms", Ref: "terms"},
+		},
+		LogicJumps: []LogicJump{
+			{From: "start", To: "loop", If: true},
+			{From: "start", To: "loop", If: false},
+			{From: "loop", To: "start", If: true},
+			{From: "text", To: "terms", If: true},
+			{From: "terms", To: "missing", If: true},
+			{From: "loop", To: "terms", If: true},
+		},
+	}
+
+	assert.Equal(t, map[string]LogicIssueKind{
+		"logic_jumps[2]":      CyclicJump,
+		"logic_jumps[3].from": NonBooleanSource,
+		"logic_jumps[4].to":   UnknownRef,
+		"logic_jumps[5]":      ConflictingJump,
+		"fields[1]":           UnreachableField,
+	}, issueKinds(form.AnalyzeLogic()))
+
+	assert.Nil(t, form.Validate(), "the API accepts forms with logic issues, so they should not make the form invalid")
+}
+
+func TestAnalyzeLogicBackwardJump(t *testing.T) {
+	form := Form{
+		Title: "Back",
+		Fields: []Field{
+			{Type: ShortText, Question: "Intro", Ref: "intro"},
+			{Type: YesNo, Question: "Again?", Ref: "again"},
+			{Type: Statement, Question: "Bye", Ref: "bye"},
+		},
+		LogicJumps: []LogicJump{
+			{From: "intro", To: "bye", If: true},
+			{From: "again", To: "intro", If: true},
+		},
+	}
+	kinds := issueKinds(form.AnalyzeLogic())
+	assert.Equal(t, NonBooleanSource, kinds["logic_jumps[0].from"])
+	assert.Equal(t, CyclicJump, kinds["logic_jumps[1]"], "intro can reach again through the default flow")
+}
+
+func TestNextField(t *testing.T) {
+	form := Form{
+		Title: "Branching",
+		Fields: []Field{
+			{Type: YesNo, Question: "Jump?", Ref: "jump"},
+			{Type: ShortText, Question: "Skipped on yes", Ref: "skipped"},
+			{Type: Rating, Question: "Rate", Ref: "rate", Steps: 5},
+		},
+		LogicJumps: []LogicJump{{From: "jump", To: "rate", If: true}},
+	}
+	yes, no := true, false
+
+	assert.Equal(t, 1, form.NextField(0, nil), "unanswered fields should be followed by the next field")
+	assert.Equal(t, 2, form.NextField(0, map[string]Answer{"jump": {Type: BooleanAnswer, Boolean: &yes}}))
+	assert.Equal(t, 1, form.NextField(0, map[string]Answer{"jump": {Type: BooleanAnswer, Boolean: &no}}))
+	assert.Equal(t, 2, form.NextField(1, map[string]Answer{"skipped": {Type: TextAnswer, Text: "text"}}))
+	assert.Equal(t, 3, form.NextField(2, nil), "the form should be over after the last field")
+}
+
+func TestSimulateLogic(t *testing.T) {
+	form := Form{
+		Title: "Branching",
+		Fields: []Field{
+			{Type: Statement, Question: "Welcome"},
+			{Type: YesNo, Question: "Customer?", Ref: "customer"},
+			{Type: YesNo, Question: "Unhappy?", Ref: "unhappy"},
+			{Type: LongText, Question: "Why?", Ref: "why"},
+			{Type: Legal, Question: "Terms", Ref: "terms"}, // both answers go to the next field
+			{Type: Email, Question: "Email", Ref: "email"},
+		},
+		LogicJumps: []LogicJump{
+			{From: "customer", To: "email", If: false},
+			{From: "unhappy", To: "terms", If: false},
+		},
+	}
+
+	simulation := form.SimulateLogic()
+	assert.False(t, simulation.Truncated)
+	assert.Len(t, simulation.Paths, 3)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, simulation.Paths[0].Fields)
+	assert.Equal(t, map[string]bool{"customer": true, "unhappy": true}, simulation.Paths[0].Answers)
+	assert.Equal(t, 0.25, simulation.Paths[0].Probability)
+	assert.Equal(t, []int{0, 1, 5}, simulation.Paths[2].Fields)
+
+	assert.Equal(t, 2, simulation.MinQuestions, "statements should not be counted")
+	assert.Equal(t, 5, simulation.MaxQuestions)
+	assert.Equal(t, 0.25*5+0.25*4+0.5*2, simulation.ExpectedQuestions)
+	assert.Equal(t, []float64{1, 1, 0.5, 0.25, 0.5, 1}, simulation.Reach)
+	assert.Equal(t, []int{3}, simulation.RareFields(0.3))
+}
+
+func TestSimulateLogicCycle(t *testing.T) {
+	form := Form{
+		Title: "Loop",
+		Fields: []Field{
+			{Type: ShortText, Question: "Name", Ref: "name"},
+			{Type: YesNo, Question: "Again?", Ref: "again"},
+		},
+		LogicJumps: []LogicJump{{From: "again", To: "name", If: true}},
+	}
+
+	simulation := form.SimulateLogic()
+	assert.Len(t, simulation.Paths, 2)
+	assert.True(t, simulation.Paths[0].Cyclic, "the path jumping back should be cut")
+	assert.False(t, simulation.Paths[1].Cyclic)
+	assert.Equal(t, 2, simulation.MinQuestions)
+	assert.Equal(t, 2, simulation.MaxQuestions)
+}
+
+func TestSimulateLogicTruncated(t *testing.T) {
+	// each question can skip the next field, so there are 2^14 paths
+	form := Form{Title: "Many paths"}
+	for i := 0; i < 14; i++ {
+		ref := fmt.Sprintf("skip%d", i)
+		form.Fields = append(form.Fields, Field{Type: YesNo, Question: "Skip?", Ref: ref}, Field{Type: ShortText, Question: "Why not?"})
+		form.LogicJumps = append(form.LogicJumps, LogicJump{From: ref, To: fmt.Sprintf("skip%d", i+1), If: true})
+	}
+	form.Fields = append(form.Fields, Field{Type: Email, Question: "Email", Ref: "skip14"})
+
+	simulation := form.SimulateLogic()
+	assert.True(t, simulation.Truncated)
+	assert.Len(t, simulation.Paths, MaxSimulatedPaths)
+	assert.Equal(t, 15, simulation.MinQuestions, "the figures should cover the paths that are not enumerated")
+	assert.Equal(t, 29, simulation.MaxQuestions)
+	assert.Equal(t, 15+14*0.5, simulation.ExpectedQuestions)
+	for i := 1; i < 28; i += 2 {
+		assert.Equal(t, 0.5, simulation.Reach[i])
+	}
+	assert.Len(t, simulation.RareFields(0.6), 14)
+
+	// with a cycle too, the figures can't be computed
+	form.Fields = append(form.Fields, Field{Type: YesNo, Question: "Again?", Ref: "again"})
+	form.LogicJumps = append(form.LogicJumps, LogicJump{From: "again", To: "skip0", If: true})
+	simulation = form.SimulateLogic()
+	assert.True(t, simulation.Truncated)
+	assert.Nil(t, simulation.Reach)
+	assert.Equal(t, 0.0, simulation.ExpectedQuestions)
+	assert.Nil(t, simulation.RareFields(0.6))
+}
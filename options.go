@@ -0,0 +1,88 @@
+package typeform
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientOption is used to configure a Client in NewClient
+type ClientOption func(client *Client) error
+
+// WithHTTPClient sets the *http.Client used for making the requests to the API
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) error {
+		if httpClient == nil {
+			return errors.New("http client is nil")
+		}
+		client.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for making the requests to the API
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(client *Client) error {
+		if transport == nil {
+			return errors.New("transport is nil")
+		}
+		httpClient := *client.httpClient
+		httpClient.Transport = transport
+		client.httpClient = &httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for each request made to the API
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout is negative: %v", timeout)
+		}
+		httpClient := *client.httpClient
+		httpClient.Timeout = timeout
+		client.httpClient = &httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets the base URL of the API for this client only;
+// clients without a base URL use APIDomain
+func WithBaseURL(baseURL string) ClientOption {
+	return func(client *Client) error {
+		parsedURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		if parsedURL.Scheme == "" || parsedURL.Host == "" {
+			return fmt.Errorf("base URL must be absolute: %q", baseURL)
+		}
+		client.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(client *Client) error {
+		if userAgent == "" {
+			return errors.New("user agent is empty")
+		}
+		client.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithHeader adds a header sent with each request;
+// it can be used multiple times, also with the same key
+func WithHeader(key, value string) ClientOption {
+	return func(client *Client) error {
+		if key == "" {
+			return errors.New("header key is empty")
+		}
+		client.headers.Add(key, value)
+		return nil
+	}
+}
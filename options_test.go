@@ -0,0 +1,54 @@
+package typeform
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"name":%q,"description":%q,"version":%q}`, name, r.Header.Get("User-Agent"), r.Header.Get("X-Custom"))
+		}))
+	}
+	production := newServer("production")
+	defer production.Close()
+	standIn := newServer("stand-in")
+	defer standIn.Close()
+
+	productionClient, err := NewClient(Latest, WithBaseURL(production.URL), WithTimeout(time.Second))
+	assert.Nil(t, err, "no error should occur")
+	productionClient.SetAPIToken("token")
+
+	standInClient, err := NewClient(Latest, WithBaseURL(standIn.URL), WithUserAgent("stand-in-agent"), WithHeader("X-Custom", "custom"))
+	assert.Nil(t, err, "no error should occur")
+	standInClient.SetAPIToken("token")
+
+	productionInfo, err := productionClient.BaseInfo()
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, "production", productionInfo.Name)
+	assert.Equal(t, DefaultUserAgent, productionInfo.Description)
+	assert.Equal(t, "", productionInfo.Version)
+
+	standInInfo, err := standInClient.BaseInfo()
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, "stand-in", standInInfo.Name)
+	assert.Equal(t, "stand-in-agent", standInInfo.Description)
+	assert.Equal(t, "custom", standInInfo.Version)
+
+	assert.Equal(t, time.Duration(0), http.DefaultClient.Timeout, "http.DefaultClient must not be modified")
+}
+
+func TestNewClientInvalidOptions(t *testing.T) {
+	_, err := NewClient(Latest, WithBaseURL("not-absolute"))
+	assert.NotNil(t, err, "a relative base URL should be rejected")
+
+	_, err = NewClient(Latest, WithHTTPClient(nil))
+	assert.NotNil(t, err, "a nil http client should be rejected")
+}
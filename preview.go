@@ -0,0 +1,238 @@
+package typeform
+
+import (
+	_ "embed" // for previewTemplateSource
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed preview.html.tmpl
+var previewTemplateSource string
+
+var previewTemplate = template.Must(template.New("preview").Parse(previewTemplateSource))
+
+// defaultDesign is the design of the preview of forms without one
+var defaultDesign = Design{
+	Colors: Colors{
+		Question:   "#3D3D3D",
+		Button:     "#4FB0AE",
+		Answer:     "#4FB0AE",
+		Background: "#FFFFFF",
+	},
+	Font: "Source Sans Pro",
+}
+
+// ratingShapes are the symbols used to preview the shapes of rating fields
+var ratingShapes = map[string]string{
+	"star":        "★",
+	"heart":       "♥",
+	"user":        "👤",
+	"up":          "👍",
+	"crown":       "👑",
+	"cat":         "🐱",
+	"dog":         "🐶",
+	"circle":      "●",
+	"flag":        "⚑",
+	"droplet":     "💧",
+	"tick":        "✔",
+	"lightbulb":   "💡",
+	"trophy":      "🏆",
+	"cloud":       "☁",
+	"thunderbolt": "⚡",
+	"pencil":      "✎",
+	"skull":       "☠",
+}
+
+// PreviewOption configures RenderPreview
+type PreviewOption func(preview *previewConfig)
+
+type previewConfig struct {
+	design        Design
+	liveReloadURL string
+}
+
+// WithPreviewDesign sets the design of the preview; the colors and font that are not set keep their defaults
+func WithPreviewDesign(design Design) PreviewOption {
+	return func(preview *previewConfig) {
+		if design.Font != "" {
+			preview.design.Font = design.Font
+		}
+		if design.Colors.Question != "" {
+			preview.design.Colors.Question = design.Colors.Question
+		}
+		if design.Colors.Button != "" {
+			preview.design.Colors.Button = design.Colors.Button
+		}
+		if design.Colors.Answer != "" {
+			preview.design.Colors.Answer = design.Colors.Answer
+		}
+		if design.Colors.Background != "" {
+			preview.design.Colors.Background = design.Colors.Background
+		}
+	}
+}
+
+// WithLiveReload makes the preview poll versionURL every second, and reload itself when the returned text changes
+func WithLiveReload(versionURL string) PreviewOption {
+	return func(preview *previewConfig) {
+		preview.liveReloadURL = versionURL
+	}
+}
+
+// previewField is a field, as shown by the preview
+type previewField struct {
+	Field
+	Number    int      // The number of the question; 0 for statements
+	Choices   []Choice // The choices, in the order they are shown
+	Symbol    string   // For rating fields, the symbol of the shape
+	Steps     []int    // For rating and opinion scale fields, the values of the steps
+	Jumps     []previewJump
+	InputType string // For fields answered with a text input, the type of the input
+	Min, Max  string // For number fields, the bounds of the input; "" if not set
+}
+
+type previewJump struct {
+	Answer string
+	To     string
+}
+
+// previewPage is the data of the preview template
+type previewPage struct {
+	Form          Form
+	Design        Design
+	Fields        []previewField
+	LiveReloadURL string
+	Errors        []string
+}
+
+// RenderPreview writes a self-contained HTML page showing what the form will look like
+func RenderPreview(w io.Writer, form Form, options ...PreviewOption) error {
+	preview := previewConfig{design: defaultDesign}
+	for _, option := range options {
+		option(&preview)
+	}
+
+	page := previewPage{
+		Form:          form,
+		Design:        preview.design,
+		LiveReloadURL: preview.liveReloadURL,
+	}
+	page.Fields = previewFields(form)
+	return previewTemplate.Execute(w, page)
+}
+
+// RenderPreviewError writes an HTML page showing why a form cannot be previewed,
+// e.g. the errors of its definition file
+func RenderPreviewError(w io.Writer, err error, options ...PreviewOption) error {
+	preview := previewConfig{design: defaultDesign}
+	for _, option := range options {
+		option(&preview)
+	}
+	return previewTemplate.Execute(w, previewPage{
+		Form:          Form{Title: "Invalid form"},
+		Design:        preview.design,
+		LiveReloadURL: preview.liveReloadURL,
+		Errors:        strings.Split(err.Error(), "\n"),
+	})
+}
+
+func previewFields(form Form) []previewField {
+	fieldsByRef := map[string]Field{}
+	for _, field := range form.Fields {
+		if field.Ref != "" {
+			fieldsByRef[field.Ref] = field
+		}
+	}
+
+	fields := make([]previewField, len(form.Fields))
+	number := 0
+	for i, field := range form.Fields {
+		preview := previewField{Field: field, Choices: shownChoices(field)}
+		if field.Type != Statement {
+			number++
+			preview.Number = number
+		}
+
+		switch field.Type {
+		case Rating, OpinionScale:
+			if field.Type == Rating {
+				preview.Symbol = ratingShapes[field.Shape]
+				if preview.Symbol == "" {
+					preview.Symbol = ratingShapes["star"]
+				}
+			}
+			first, last := stepRange(field)
+			for step := first; step <= last; step++ {
+				preview.Steps = append(preview.Steps, step)
+			}
+		case ShortText:
+			preview.InputType = "text"
+		case Number:
+			preview.InputType = "number"
+			minValue, hasMin, maxValue, hasMax := field.Bounds()
+			if hasMin {
+				preview.Min = strconv.Itoa(minValue)
+			}
+			if hasMax {
+				preview.Max = strconv.Itoa(maxValue)
+			}
+		case Email:
+			preview.InputType = "email"
+		case Website:
+			preview.InputType = "url"
+		}
+
+		for _, jump := range form.LogicJumps {
+			if field.Ref == "" || jump.From != field.Ref {
+				continue
+			}
+			answer := "no"
+			if jump.If {
+				answer = "yes"
+			}
+			to := jump.To
+			if target, ok := fieldsByRef[jump.To]; ok {
+				to = target.Question
+			}
+			preview.Jumps = append(preview.Jumps, previewJump{Answer: answer, To: to})
+		}
+
+		fields[i] = preview
+	}
+	return fields
+}
+
+// shownChoices returns the choices of the field in the order they are shown:
+// the choices of dropdowns can be sorted alphabetically; random orders are not reproduced
+func shownChoices(field Field) []Choice {
+	if field.Type != Dropdown || !field.AlphabeticalOrder {
+		return field.Choices
+	}
+	choices := append([]Choice(nil), field.Choices...)
+	sort.SliceStable(choices, func(a, b int) bool {
+		return strings.ToLower(choices[a].Label) < strings.ToLower(choices[b].Label)
+	})
+	return choices
+}
+
+// stepRange returns the values of the first and last steps of a rating or opinion scale field;
+// fields without steps have the default steps of the API: 5 for rating fields, 11 for opinion scale fields
+func stepRange(field Field) (first, last int) {
+	steps := field.Steps
+	if field.Type == Rating {
+		if steps == 0 {
+			steps = 5
+		}
+		return 1, steps
+	}
+	if steps == 0 {
+		steps = 11
+	}
+	if field.StartAtOne {
+		return 1, steps
+	}
+	return 0, steps - 1
+}
@@ -0,0 +1,50 @@
+package typeform
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPreview(t *testing.T) {
+	form, err := NewForm("Customer <survey>").
+		YesNo("Are you happy?", Ref("happy")).
+		MultipleChoice("What could we do better?", []string{"Prices", "Support"}, AddOtherChoice()).
+		Dropdown("Country", []string{"Italy", "France"}, AlphabeticalOrder()).
+		Rating("Rate us", Shape("heart"), Steps(3)).
+		OpinionScale("Recommend us?", Steps(5), ScaleLabels("No", "", "Yes"), StartAtOne()).
+		Number("How many?", MinValue(0), MaxValue(5)).
+		Statement("Thanks!", ButtonText("Done"), Ref("thanks")).
+		JumpIf("happy", true, "thanks").
+		Build()
+	assert.Nil(t, err, "no error should occur")
+
+	var page bytes.Buffer
+	err = RenderPreview(&page, form, WithPreviewDesign(Design{Colors: Colors{Background: "#000000"}}), WithLiveReload("/version"))
+	assert.Nil(t, err, "no error should occur")
+	html := page.String()
+
+	assert.Contains(t, html, "Customer &lt;survey&gt;", "the content should be escaped")
+	assert.Contains(t, html, "background: #000000", "the colors of the design should be used")
+	assert.Contains(t, html, "color: #3D3D3D", "the colors not set by the design should keep their defaults")
+	assert.Contains(t, html, `type="radio"`)
+	assert.Contains(t, html, "Other")
+	assert.True(t, strings.Index(html, "<option>France</option>") < strings.Index(html, "<option>Italy</option>"), "dropdown choices should be sorted")
+	assert.Contains(t, html, "♥♥♥")
+	assert.Contains(t, html, `<span class="choice step">1</span>`)
+	assert.Contains(t, html, `<span class="choice step">5</span>`)
+	assert.Contains(t, html, "<span>No</span>")
+	assert.Contains(t, html, `type="number" min="0" max="5"`, "an explicit zero bound should be kept")
+	assert.Contains(t, html, ">Done</button>")
+	assert.Contains(t, html, "If yes, jump to “Thanks!”")
+	assert.Contains(t, html, `fetch("/version"`)
+	assert.NotContains(t, html, "ZgotmplZ", "no value should be rejected by the template")
+
+	page.Reset()
+	err = RenderPreviewError(&page, errors.New("form.yaml:3:1: title: is required"))
+	assert.Nil(t, err, "no error should occur")
+	assert.Contains(t, page.String(), "form.yaml:3:1: title: is required")
+}
@@ -0,0 +1,157 @@
+package typeform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate of the requests made by a Client;
+// it is shared by all the methods of the Client, and must be safe for concurrent use
+type RateLimiter interface {
+	// Wait blocks until a request can be made, or until ctx is done
+	Wait(ctx context.Context) error
+}
+
+// RateLimitObserver is implemented by rate limiters that adapt
+// to the rate-limit headers of the API responses
+type RateLimitObserver interface {
+	Observe(responseHeaders http.Header)
+}
+
+// WithRateLimiter sets the rate limiter consulted before each request (and each retry)
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(client *Client) error {
+		if limiter == nil {
+			return errors.New("rate limiter is nil")
+		}
+		client.rateLimiter = limiter
+		return nil
+	}
+}
+
+// TokenBucket is a token bucket RateLimiter; it also implements RateLimitObserver,
+// pausing when the API reports that the quota is exhausted via the
+// X-RateLimit-Remaining and X-RateLimit-Reset headers, or asks to wait via Retry-After.
+type TokenBucket struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// NewTokenBucket creates a new TokenBucket allowing rate requests per second on average,
+// and bursts of up to burst requests
+func NewTokenBucket(rate float64, burst int) (*TokenBucket, error) {
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return nil, fmt.Errorf("rate must be a positive number: %v", rate)
+	}
+	if burst < 1 {
+		return nil, fmt.Errorf("burst must be at least 1: %v", burst)
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}, nil
+}
+
+// Wait blocks until a token is available, or until ctx is done
+func (bucket *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := bucket.take()
+		if wait == 0 {
+			return nil
+		}
+		err := sleepContext(ctx, wait)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// take takes a token if one is available, otherwise it returns how long to wait before trying again
+func (bucket *TokenBucket) take() time.Duration {
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	now := bucket.now()
+	bucket.refill(now)
+
+	if now.Before(bucket.pausedUntil) {
+		return bucket.pausedUntil.Sub(now)
+	}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / bucket.rate * float64(time.Second))
+	if wait <= 0 {
+		wait = time.Millisecond
+	}
+	return wait
+}
+
+func (bucket *TokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(bucket.last)
+	if elapsed <= 0 {
+		return
+	}
+	bucket.tokens = math.Min(bucket.burst, bucket.tokens+elapsed.Seconds()*bucket.rate)
+	bucket.last = now
+}
+
+// Observe adapts the bucket to the rate-limit headers of a response
+func (bucket *TokenBucket) Observe(responseHeaders http.Header) {
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	now := bucket.now()
+	bucket.refill(now)
+
+	if wait, ok := parseRetryAfter(responseHeaders.Get("Retry-After"), now); ok {
+		bucket.pauseUntil(now.Add(wait))
+	}
+
+	remaining, err := strconv.ParseFloat(responseHeaders.Get("X-RateLimit-Remaining"), 64)
+	if err != nil || remaining < 0 {
+		return
+	}
+	bucket.tokens = math.Min(bucket.tokens, remaining)
+	if remaining > 0 {
+		return
+	}
+	if reset, ok := parseRateLimitReset(responseHeaders.Get("X-RateLimit-Reset"), now); ok {
+		bucket.pauseUntil(reset)
+	}
+}
+
+func (bucket *TokenBucket) pauseUntil(until time.Time) {
+	if until.After(bucket.pausedUntil) {
+		bucket.pausedUntil = until
+	}
+}
+
+// parseRateLimitReset parses the value of a X-RateLimit-Reset header,
+// which is either a number of seconds or a unix timestamp
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+	// values larger than a year of seconds can only be timestamps
+	if seconds > 365*24*60*60 {
+		return time.Unix(seconds, 0), true
+	}
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
@@ -0,0 +1,80 @@
+package typeform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketBurst(t *testing.T) {
+	bucket, err := NewTokenBucket(50, 2)
+	assert.Nil(t, err, "no error should occur")
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, bucket.Wait(context.Background()))
+	}
+	assert.True(t, time.Since(start) >= 15*time.Millisecond, "the third request should wait for a new token")
+}
+
+func TestTokenBucketContext(t *testing.T) {
+	bucket, err := NewTokenBucket(0.01, 1)
+	assert.Nil(t, err, "no error should occur")
+	assert.Nil(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, bucket.Wait(ctx))
+}
+
+func TestTokenBucketObserve(t *testing.T) {
+	now := time.Date(2017, 2, 28, 12, 0, 0, 0, time.UTC)
+	bucket, err := NewTokenBucket(10, 10)
+	assert.Nil(t, err, "no error should occur")
+	bucket.now = func() time.Time { return now }
+	bucket.last = now
+
+	bucket.Observe(http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"30"}})
+	assert.Equal(t, 30*time.Second, bucket.take(), "an exhausted quota should pause the bucket until reset")
+
+	now = now.Add(31 * time.Second)
+	assert.Equal(t, time.Duration(0), bucket.take())
+}
+
+func TestClientSharesRateLimiter(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer testServer.Close()
+
+	bucket, err := NewTokenBucket(100, 1)
+	assert.Nil(t, err, "no error should occur")
+
+	limitedClient, err := NewClient(Latest, WithBaseURL(testServer.URL), WithRateLimiter(bucket))
+	assert.Nil(t, err, "no error should occur")
+	limitedClient.SetAPIToken("token")
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				_, err := limitedClient.GetForm("abc")
+				assert.Nil(t, err, "no error should occur")
+			} else {
+				_, err := limitedClient.GetImage("abc")
+				assert.Nil(t, err, "no error should occur")
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.True(t, time.Since(start) >= 35*time.Millisecond, "requests of all methods should share the same limiter")
+}
@@ -0,0 +1,387 @@
+package typeform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DesiredState is the set of designs, forms and URLs that should exist, by logical name
+type DesiredState struct {
+	Designs map[string]Design          // The designs, by name
+	Forms   map[string]*FormDefinition // The forms, by name; their DesignName refers to Designs
+	URLs    map[string]string          // The form name each URL links to, by URL name
+}
+
+// DesiredStateFromDefinitions returns the desired state of a set of form definitions, by form name:
+// the designs defined in the files, the forms, and a URL named after each form linking to it
+func DesiredStateFromDefinitions(definitions map[string]*FormDefinition) (DesiredState, error) {
+	desired := DesiredState{
+		Designs: map[string]Design{},
+		Forms:   map[string]*FormDefinition{},
+		URLs:    map[string]string{},
+	}
+	for _, formName := range sortedKeys(definitions) {
+		definition := definitions[formName]
+		for designName, design := range definition.Designs {
+			if other, ok := desired.Designs[designName]; ok && other != design {
+				return desired, fmt.Errorf("design %q is defined differently by several forms", designName)
+			}
+			desired.Designs[designName] = design
+		}
+		desired.Forms[formName] = definition
+		desired.URLs[formName] = formName
+	}
+	return desired, nil
+}
+
+// StateEntry is a resource created by ApplyPlan
+type StateEntry struct {
+	ID     string `json:"id"`
+	Hash   string `json:"hash,omitempty"`    // The hash of the definition the resource was created from
+	Form   string `json:"form,omitempty"`    // For URLs, the name of the form they link to
+	FormID string `json:"form_id,omitempty"` // For URLs, the ID of the version of the form they link to
+}
+
+// State maps the logical names of the resources created by ApplyPlan to their IDs
+type State struct {
+	Designs map[string]StateEntry `json:"designs"`
+	Forms   map[string]StateEntry `json:"forms"`
+	URLs    map[string]StateEntry `json:"urls"`
+}
+
+// NewState returns an empty State
+func NewState() *State {
+	return &State{
+		Designs: map[string]StateEntry{},
+		Forms:   map[string]StateEntry{},
+		URLs:    map[string]StateEntry{},
+	}
+}
+
+// LoadState reads a state file; a missing file is an empty state
+func LoadState(path string) (*State, error) {
+	state := NewState()
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	if state.Designs == nil {
+		state.Designs = map[string]StateEntry{}
+	}
+	if state.Forms == nil {
+		state.Forms = map[string]StateEntry{}
+	}
+	if state.URLs == nil {
+		state.URLs = map[string]StateEntry{}
+	}
+	return state, nil
+}
+
+// Save writes the state to a file, atomically
+func (state *State) Save(path string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	temporary, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = temporary.Write(append(data, '\n'))
+	if err == nil {
+		err = temporary.Sync()
+	}
+	closeErr := temporary.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temporary.Name())
+		return err
+	}
+	return os.Rename(temporary.Name(), path)
+}
+
+// ActionKind is the kind of an Action of a Plan
+type ActionKind string
+
+const (
+	// CreateDesignAction creates a design
+	CreateDesignAction ActionKind = "create_design"
+	// CreateFormAction creates a form, or a new version of a changed form
+	CreateFormAction ActionKind = "create_form"
+	// CreateURLAction creates a URL
+	CreateURLAction ActionKind = "create_url"
+	// ModifyURLAction repoints a URL to another form
+	ModifyURLAction ActionKind = "modify_url"
+	// DeleteURLAction deletes a URL that is no longer desired
+	DeleteURLAction ActionKind = "delete_url"
+)
+
+// Action is a change made by ApplyPlan
+type Action struct {
+	Kind   ActionKind
+	Name   string // The logical name of the resource
+	Reason string
+	Form   string // For URLs, the name of the form they must link to
+	Hash   string // For designs and forms, the hash of their definition
+
+	design     Design
+	form       Form
+	designName string
+}
+
+func (action Action) String() string {
+	switch action.Kind {
+	case CreateDesignAction:
+		return fmt.Sprintf("+ create design %q (%s)", action.Name, action.Reason)
+	case CreateFormAction:
+		return fmt.Sprintf("+ create form %q (%s)", action.Name, action.Reason)
+	case CreateURLAction:
+		return fmt.Sprintf("+ create url %q -> form %q", action.Name, action.Form)
+	case ModifyURLAction:
+		return fmt.Sprintf("~ repoint url %q -> form %q (%s)", action.Name, action.Form, action.Reason)
+	case DeleteURLAction:
+		return fmt.Sprintf("- delete url %q (%s)", action.Name, action.Reason)
+	default:
+		return fmt.Sprintf("? %s %q", action.Kind, action.Name)
+	}
+}
+
+// Plan is the list of actions reconciling a State with a DesiredState
+type Plan struct {
+	Actions []Action
+}
+
+// Empty reports whether the plan has no actions
+func (plan *Plan) Empty() bool {
+	return len(plan.Actions) == 0
+}
+
+func (plan *Plan) String() string {
+	if plan.Empty() {
+		return "no changes"
+	}
+	lines := make([]string, len(plan.Actions))
+	for i, action := range plan.Actions {
+		lines[i] = action.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hashOf returns a short hash of the JSON of values
+func hashOf(values ...interface{}) string {
+	hash := sha256.New()
+	for _, value := range values {
+		data, _ := json.Marshal(value)
+		hash.Write(data)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+func sortedKeys(values interface{}) []string {
+	keys := []string{}
+	switch typed := values.(type) {
+	case map[string]Design:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	case map[string]*FormDefinition:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	case map[string]string:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	case map[string]StateEntry:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ComputePlan returns the actions creating the designs and forms that are missing or changed in state,
+// and creating, repointing or deleting URLs so that they link to the desired forms.
+// Since forms and designs cannot be modified, a changed form is created anew and its URLs are repointed
+// to the new version; a form is also created anew when its design changes
+func ComputePlan(desired DesiredState, state *State) (*Plan, error) {
+	plan := &Plan{}
+
+	designHashes := map[string]string{}
+	for _, name := range sortedKeys(desired.Designs) {
+		hash := hashOf(desired.Designs[name])
+		designHashes[name] = hash
+		entry, ok := state.Designs[name]
+		if ok && entry.Hash == hash {
+			continue
+		}
+		reason := "new"
+		if ok {
+			reason = "changed"
+		}
+		plan.Actions = append(plan.Actions, Action{Kind: CreateDesignAction, Name: name, Reason: reason, Hash: hash, design: desired.Designs[name]})
+	}
+
+	recreatedForms := map[string]bool{}
+	for _, name := range sortedKeys(desired.Forms) {
+		definition := desired.Forms[name]
+		form := definition.Form
+		designHash := ""
+		if definition.DesignName != "" {
+			var ok bool
+			designHash, ok = designHashes[definition.DesignName]
+			if !ok {
+				return nil, fmt.Errorf("form %q: unknown design %q", name, definition.DesignName)
+			}
+			form.DesignID = ""
+		}
+		hash := hashOf(form, definition.DesignName, designHash)
+		entry, ok := state.Forms[name]
+		if ok && entry.Hash == hash {
+			continue
+		}
+		reason := "new"
+		if ok {
+			reason = "changed, new version"
+		}
+		recreatedForms[name] = true
+		plan.Actions = append(plan.Actions, Action{Kind: CreateFormAction, Name: name, Reason: reason, Hash: hash, form: definition.Form, designName: definition.DesignName})
+	}
+
+	for _, name := range sortedKeys(desired.URLs) {
+		formName := desired.URLs[name]
+		if _, ok := desired.Forms[formName]; !ok {
+			return nil, fmt.Errorf("url %q: unknown form %q", name, formName)
+		}
+		entry, ok := state.URLs[name]
+		switch {
+		case !ok:
+			plan.Actions = append(plan.Actions, Action{Kind: CreateURLAction, Name: name, Form: formName, Reason: "new"})
+		case entry.Form != formName:
+			plan.Actions = append(plan.Actions, Action{Kind: ModifyURLAction, Name: name, Form: formName, Reason: fmt.Sprintf("was form %q", entry.Form)})
+		case recreatedForms[formName] || entry.FormID != state.Forms[formName].ID:
+			plan.Actions = append(plan.Actions, Action{Kind: ModifyURLAction, Name: name, Form: formName, Reason: "new form version"})
+		}
+	}
+
+	for _, name := range sortedKeys(state.URLs) {
+		if _, ok := desired.URLs[name]; !ok {
+			plan.Actions = append(plan.Actions, Action{Kind: DeleteURLAction, Name: name, Reason: "no longer desired"})
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplyPlan makes the changes of a plan, recording the created resources in state; if statePath is not empty,
+// the state is saved after each change, so that a failed apply can be resumed by planning again.
+// Actions already reflected in state are skipped, so applying the same plan twice is harmless
+func (client *Client) ApplyPlan(ctx context.Context, plan *Plan, state *State, statePath string) error {
+	for _, action := range plan.Actions {
+		changed, err := client.applyAction(ctx, action, state)
+		if err != nil {
+			return fmt.Errorf("%s: %s", action, err)
+		}
+		if changed && statePath != "" {
+			err = state.Save(statePath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyAction makes the change of an action; it returns false if state already reflected it
+func (client *Client) applyAction(ctx context.Context, action Action, state *State) (bool, error) {
+	// the same change is requested with the same key, so that the API can deduplicate a retried creation;
+	// the key ends with the hash of the resource, or with the ID of the form a URL points to
+	idempotent := func(target string) context.Context {
+		return WithIdempotencyKey(ctx, fmt.Sprintf("%s-%s-%s", action.Kind, action.Name, target))
+	}
+
+	switch action.Kind {
+	case CreateDesignAction:
+		if state.Designs[action.Name].Hash == action.Hash {
+			return false, nil
+		}
+		designInfo, err := client.CreateDesignWithContext(idempotent(action.Hash), action.design)
+		if err != nil {
+			return false, err
+		}
+		state.Designs[action.Name] = StateEntry{ID: designInfo.ID, Hash: action.Hash}
+
+	case CreateFormAction:
+		if state.Forms[action.Name].Hash == action.Hash {
+			return false, nil
+		}
+		form := action.form
+		if action.designName != "" {
+			design, ok := state.Designs[action.designName]
+			if !ok {
+				return false, fmt.Errorf("design %q has not been created", action.designName)
+			}
+			form.DesignID = design.ID
+		}
+		formInfo, err := client.CreateFormWithContext(idempotent(action.Hash), form)
+		if err != nil {
+			return false, err
+		}
+		state.Forms[action.Name] = StateEntry{ID: formInfo.ID, Hash: action.Hash}
+
+	case CreateURLAction, ModifyURLAction:
+		form, ok := state.Forms[action.Form]
+		if !ok {
+			return false, fmt.Errorf("form %q has not been created", action.Form)
+		}
+		entry, exists := state.URLs[action.Name]
+		if exists && entry.Form == action.Form && entry.FormID == form.ID {
+			return false, nil
+		}
+		var URLInfo *URLInfo
+		var err error
+		if exists {
+			URLInfo, err = client.ModifyURLWithContext(idempotent(form.ID), entry.ID, form.ID)
+		} else {
+			URLInfo, err = client.CreateURLWithContext(idempotent(form.ID), form.ID)
+		}
+		if err != nil {
+			return false, err
+		}
+		state.URLs[action.Name] = StateEntry{ID: URLInfo.ID, Form: action.Form, FormID: form.ID}
+
+	case DeleteURLAction:
+		entry, ok := state.URLs[action.Name]
+		if !ok {
+			return false, nil
+		}
+		err := client.DeleteURLWithContext(ctx, entry.ID)
+		if err != nil && !IsNotFound(err) {
+			return false, err
+		}
+		delete(state.URLs, action.Name)
+
+	default:
+		return false, fmt.Errorf("unknown action %q", action.Kind)
+	}
+	return true, nil
+}
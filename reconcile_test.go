@@ -0,0 +1,150 @@
+package typeform_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+	"github.com/gagliardetto/go-ask-awesomely/typeformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustDefinition(t *testing.T, definition string) *typeform.FormDefinition {
+	formDefinition, err := typeform.ParseFormDefinition("test.yaml", []byte(definition))
+	assert.Nil(t, err, "no error should occur")
+	return formDefinition
+}
+
+func TestPlanAndApply(t *testing.T) {
+	server := typeformtest.NewServer()
+	defer server.Close()
+	reconcileClient, err := server.NewClient()
+	assert.Nil(t, err, "no error should occur")
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	ctx := context.Background()
+
+	planAndApply := func(definitions map[string]*typeform.FormDefinition) (*typeform.Plan, *typeform.State) {
+		desired, err := typeform.DesiredStateFromDefinitions(definitions)
+		assert.Nil(t, err, "no error should occur")
+		state, err := typeform.LoadState(statePath)
+		assert.Nil(t, err, "no error should occur")
+		plan, err := typeform.ComputePlan(desired, state)
+		assert.Nil(t, err, "no error should occur")
+		assert.Nil(t, reconcileClient.ApplyPlan(ctx, plan, state, statePath))
+		return plan, state
+	}
+	kinds := func(plan *typeform.Plan) []typeform.ActionKind {
+		kinds := []typeform.ActionKind{}
+		for _, action := range plan.Actions {
+			kinds = append(kinds, action.Kind)
+		}
+		return kinds
+	}
+
+	survey := mustDefinition(t, `
+title: Survey
+design: brand
+designs:
+  brand: {font: Arial}
+fields: [{type: short_text, question: "Name?"}]
+`)
+	feedback := mustDefinition(t, `
+title: Feedback
+fields: [{type: long_text, question: Tell us}]
+`)
+
+	plan, state := planAndApply(map[string]*typeform.FormDefinition{"survey": survey, "feedback": feedback})
+	assert.Equal(t, []typeform.ActionKind{
+		typeform.CreateDesignAction,
+		typeform.CreateFormAction, typeform.CreateFormAction,
+		typeform.CreateURLAction, typeform.CreateURLAction,
+	}, kinds(plan), plan.String())
+	form, _ := server.Form(state.Forms["survey"].ID)
+	assert.Equal(t, state.Designs["brand"].ID, form.DesignID, "the design should be resolved by name")
+
+	// applying the same definitions again changes nothing
+	plan, _ = planAndApply(map[string]*typeform.FormDefinition{"survey": survey, "feedback": feedback})
+	assert.True(t, plan.Empty(), plan.String())
+
+	// a changed form gets a new version, and its URL is repointed; a removed form's URL is deleted
+	oldSurveyID := state.Forms["survey"].ID
+	survey.Form.Title = "Survey v2"
+	plan, state = planAndApply(map[string]*typeform.FormDefinition{"survey": survey})
+	assert.Equal(t, []typeform.ActionKind{typeform.CreateFormAction, typeform.ModifyURLAction, typeform.DeleteURLAction}, kinds(plan), plan.String())
+	assert.NotEqual(t, oldSurveyID, state.Forms["survey"].ID)
+
+	URLInfo, ok := server.FormURL(state.URLs["survey"].ID)
+	assert.True(t, ok)
+	assert.Equal(t, state.Forms["survey"].ID, URLInfo.FormID, "the URL should link to the new version")
+	_, ok = state.URLs["feedback"]
+	assert.False(t, ok, "the stale URL should be removed from the state")
+}
+
+func TestApplyPlanResumes(t *testing.T) {
+	server := typeformtest.NewServer()
+	defer server.Close()
+	reconcileClient, err := server.NewClient(typeform.WithRetryPolicy(typeform.RetryPolicy{MaxAttempts: 1}))
+	assert.Nil(t, err, "no error should occur")
+
+	desired, err := typeform.DesiredStateFromDefinitions(map[string]*typeform.FormDefinition{
+		"survey": mustDefinition(t, `{title: Survey, fields: [{type: email, question: "Email?"}]}`),
+	})
+	assert.Nil(t, err, "no error should occur")
+
+	state := typeform.NewState()
+	plan, err := typeform.ComputePlan(desired, state)
+	assert.Nil(t, err, "no error should occur")
+
+	server.InjectFault(typeformtest.Fault{Path: "/urls", StatusCode: 503, Times: 1})
+	assert.NotNil(t, reconcileClient.ApplyPlan(context.Background(), plan, state, ""), "the URL creation should fail")
+	assert.Len(t, state.Forms, 1, "the created form should be recorded")
+
+	plan, err = typeform.ComputePlan(desired, state)
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, 1, len(plan.Actions), plan.String())
+	assert.Equal(t, typeform.CreateURLAction, plan.Actions[0].Kind)
+	assert.Nil(t, reconcileClient.ApplyPlan(context.Background(), plan, state, ""))
+}
+
+// keyRecorder records the idempotency keys of the requests to the URLs
+type keyRecorder struct {
+	keys []string
+}
+
+func (recorder *keyRecorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	if strings.Contains(request.URL.Path, "/urls") {
+		recorder.keys = append(recorder.keys, request.Header.Get(typeform.IdempotencyKeyHeader))
+	}
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestApplyPlanURLIdempotencyKeys(t *testing.T) {
+	server := typeformtest.NewServer()
+	defer server.Close()
+	recorder := &keyRecorder{}
+	reconcileClient, err := server.NewClient(typeform.WithTransport(recorder))
+	assert.Nil(t, err, "no error should occur")
+
+	state := typeform.NewState()
+	apply := func(title string) {
+		desired, err := typeform.DesiredStateFromDefinitions(map[string]*typeform.FormDefinition{
+			"survey": mustDefinition(t, `{title: `+title+`, fields: [{type: email, question: "Email?"}]}`),
+		})
+		assert.Nil(t, err, "no error should occur")
+		plan, err := typeform.ComputePlan(desired, state)
+		assert.Nil(t, err, "no error should occur")
+		assert.Nil(t, reconcileClient.ApplyPlan(context.Background(), plan, state, ""))
+	}
+
+	apply("Survey")
+	firstFormID := state.Forms["survey"].ID
+	apply("Survey v2")
+	secondFormID := state.Forms["survey"].ID
+
+	// repointing the URL to each new version of the form is a different change
+	assert.Equal(t, []string{"create_url-survey-" + firstFormID, "modify_url-survey-" + secondFormID}, recorder.keys)
+}
@@ -0,0 +1,214 @@
+package typeform
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ResponsesQuery filters and orders the responses returned by GetResponses
+type ResponsesQuery struct {
+	Completed *bool     // If set, only completed (true) or incomplete (false) responses are returned
+	Since     time.Time // If set, only responses submitted since this time are returned
+	Until     time.Time // If set, only responses submitted until this time are returned
+	Offset    int       // The number of responses to skip
+	Limit     int       // The maximum number of responses to return; 0 uses the API default
+	OrderBy   string    // The order of the responses, e.g. "date_submit,desc"
+}
+
+func (query ResponsesQuery) values() url.Values {
+	values := url.Values{}
+	if query.Completed != nil {
+		values.Set("completed", strconv.FormatBool(*query.Completed))
+	}
+	if !query.Since.IsZero() {
+		values.Set("since", strconv.FormatInt(query.Since.Unix(), 10))
+	}
+	if !query.Until.IsZero() {
+		values.Set("until", strconv.FormatInt(query.Until.Unix(), 10))
+	}
+	if query.Offset > 0 {
+		values.Set("offset", strconv.Itoa(query.Offset))
+	}
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.OrderBy != "" {
+		values.Set("order_by", query.OrderBy)
+	}
+	return values
+}
+
+// Responses is a page of the responses submitted to a form
+type Responses struct {
+	TotalItems int        `json:"total_items"` // The number of responses matching the query, in all the pages
+	PageCount  int        `json:"page_count"`
+	Items      []Response `json:"items"`
+}
+
+// Response is a response submitted (or started) by a respondent
+type Response struct {
+	ResponseID  string            `json:"response_id"`
+	Token       string            `json:"token"`
+	LandedAt    time.Time         `json:"landed_at"`
+	SubmittedAt time.Time         `json:"submitted_at"` // Zero if the response is incomplete
+	Metadata    ResponseMetadata  `json:"metadata"`
+	Hidden      map[string]string `json:"hidden,omitempty"` // The hidden fields of the form URL
+	Answers     []Answer          `json:"answers"`
+}
+
+// ResponseMetadata is info about the respondent
+type ResponseMetadata struct {
+	UserAgent string `json:"user_agent"`
+	Platform  string `json:"platform"`
+	Referer   string `json:"referer"`
+	NetworkID string `json:"network_id"`
+	Browser   string `json:"browser"`
+}
+
+// Completed tells whether the response has been submitted
+func (response Response) Completed() bool {
+	return !response.SubmittedAt.IsZero()
+}
+
+// AnswersByRef returns the answers of the response keyed by the Ref of their field;
+// answers to fields without a Ref are not included
+func (response Response) AnswersByRef() map[string]Answer {
+	answers := map[string]Answer{}
+	for _, answer := range response.Answers {
+		if answer.Field.Ref != "" {
+			answers[answer.Field.Ref] = answer
+		}
+	}
+	return answers
+}
+
+// AnswersByFieldID returns the answers of the response keyed by the ID of their field
+func (response Response) AnswersByFieldID() map[string]Answer {
+	answers := map[string]Answer{}
+	for _, answer := range response.Answers {
+		answers[answer.Field.ID] = answer
+	}
+	return answers
+}
+
+// AnswerType is the type of the value of an answer
+type AnswerType string
+
+const (
+	// TextAnswer is the answer to short_text and long_text fields
+	TextAnswer AnswerType = "text"
+
+	// EmailAnswer is the answer to email fields
+	EmailAnswer AnswerType = "email"
+
+	// URLAnswer is the answer to website fields
+	URLAnswer AnswerType = "url"
+
+	// NumberAnswer is the answer to number, rating and opinion_scale fields
+	NumberAnswer AnswerType = "number"
+
+	// BooleanAnswer is the answer to yes_no and legal fields
+	BooleanAnswer AnswerType = "boolean"
+
+	// ChoiceAnswer is the answer to choice fields that allow a single selection
+	ChoiceAnswer AnswerType = "choice"
+
+	// ChoicesAnswer is the answer to choice fields that allow multiple selections
+	ChoicesAnswer AnswerType = "choices"
+)
+
+// AnswerField identifies the field an answer is for
+type AnswerField struct {
+	ID   string    `json:"id"`
+	Type FieldType `json:"type"`
+	Ref  string    `json:"ref,omitempty"`
+}
+
+// Answer is the answer to a field; only the value matching Type is set
+type Answer struct {
+	Field   AnswerField      `json:"field"`
+	Type    AnswerType       `json:"type"`
+	Text    string           `json:"text,omitempty"`
+	Email   string           `json:"email,omitempty"`
+	URL     string           `json:"url,omitempty"`
+	Number  *int             `json:"number,omitempty"`
+	Boolean *bool            `json:"boolean,omitempty"`
+	Choice  *SelectedChoice  `json:"choice,omitempty"`
+	Choices *SelectedChoices `json:"choices,omitempty"`
+}
+
+// SelectedChoice is the choice selected in a field that allows a single selection
+type SelectedChoice struct {
+	Label string `json:"label,omitempty"`
+	Other string `json:"other,omitempty"` // The text written in the "Other" choice
+}
+
+// SelectedChoices are the choices selected in a field that allows multiple selections
+type SelectedChoices struct {
+	Labels []string `json:"labels,omitempty"`
+	Other  string   `json:"other,omitempty"` // The text written in the "Other" choice
+}
+
+// Value returns the value of the answer, interpreted according to the type of its field:
+// a string for text, email and website fields, an int for number, rating and opinion_scale fields,
+// a bool for yes_no and legal fields, a SelectedChoice or SelectedChoices for choice fields;
+// nil if the answer has no value
+func (answer Answer) Value() interface{} {
+	switch answer.Field.Type {
+	case ShortText, LongText:
+		return answer.Text
+	case Email:
+		return answer.Email
+	case Website:
+		return answer.URL
+	case Number, Rating, OpinionScale:
+		if answer.Number != nil {
+			return *answer.Number
+		}
+	case YesNo, Legal:
+		if answer.Boolean != nil {
+			return *answer.Boolean
+		}
+	case MultipleChoice, PictureChoice, Dropdown:
+		if answer.Choices != nil {
+			return *answer.Choices
+		}
+		if answer.Choice != nil {
+			return *answer.Choice
+		}
+	default:
+		return answer.valueByType()
+	}
+	return nil
+}
+
+// valueByType returns the value of the answer according to its own type,
+// for fields of unknown types
+func (answer Answer) valueByType() interface{} {
+	switch answer.Type {
+	case TextAnswer:
+		return answer.Text
+	case EmailAnswer:
+		return answer.Email
+	case URLAnswer:
+		return answer.URL
+	case NumberAnswer:
+		if answer.Number != nil {
+			return *answer.Number
+		}
+	case BooleanAnswer:
+		if answer.Boolean != nil {
+			return *answer.Boolean
+		}
+	case ChoiceAnswer:
+		if answer.Choice != nil {
+			return *answer.Choice
+		}
+	case ChoicesAnswer:
+		if answer.Choices != nil {
+			return *answer.Choices
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package typeform
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testResponsesBody = `{
+	"total_items": 1,
+	"page_count": 1,
+	"items": [{
+		"response_id": "r1",
+		"token": "r1",
+		"landed_at": "2017-02-28T10:00:00Z",
+		"submitted_at": "2017-02-28T10:05:00Z",
+		"metadata": {"user_agent": "curl", "platform": "other", "referer": "https://example.com", "network_id": "n1", "browser": "default"},
+		"hidden": {"source": "newsletter"},
+		"answers": [
+			{"field": {"id": "f1", "type": "short_text", "ref": "name"}, "type": "text", "text": "Ada"},
+			{"field": {"id": "f2", "type": "yes_no", "ref": "likes"}, "type": "boolean", "boolean": false},
+			{"field": {"id": "f3", "type": "rating"}, "type": "number", "number": 4},
+			{"field": {"id": "f4", "type": "multiple_choice", "ref": "pick"}, "type": "choices", "choices": {"labels": ["this", "that"], "other": "mine"}}
+		]
+	}]
+}`
+
+func TestGetResponses(t *testing.T) {
+	var query url.Values
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/latest/forms/abc/responses", r.URL.Path)
+		query = r.URL.Query()
+		fmt.Fprint(w, testResponsesBody)
+	}))
+	defer testServer.Close()
+
+	responsesClient, err := NewClient(Latest, WithBaseURL(testServer.URL))
+	assert.Nil(t, err, "no error should occur")
+	responsesClient.SetAPIToken("token")
+
+	completed := true
+	since := time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)
+	responses, err := responsesClient.GetResponses("abc", ResponsesQuery{
+		Completed: &completed,
+		Since:     since,
+		Offset:    20,
+		Limit:     10,
+		OrderBy:   "date_submit,desc",
+	})
+	assert.Nil(t, err, "no error should occur")
+
+	assert.Equal(t, url.Values{
+		"completed": {"true"},
+		"since":     {fmt.Sprint(since.Unix())},
+		"offset":    {"20"},
+		"limit":     {"10"},
+		"order_by":  {"date_submit,desc"},
+	}, query)
+
+	assert.Equal(t, 1, responses.TotalItems)
+	response := responses.Items[0]
+	assert.True(t, response.Completed())
+	assert.Equal(t, "newsletter", response.Hidden["source"])
+	assert.Equal(t, "curl", response.Metadata.UserAgent)
+
+	byRef := response.AnswersByRef()
+	assert.Len(t, byRef, 3, "answers to fields without a ref are not keyed by ref")
+	assert.Equal(t, "Ada", byRef["name"].Value())
+	assert.Equal(t, false, byRef["likes"].Value())
+	assert.Equal(t, SelectedChoices{Labels: []string{"this", "that"}, Other: "mine"}, byRef["pick"].Value())
+	assert.Equal(t, 4, response.AnswersByFieldID()["f3"].Value())
+}
@@ -0,0 +1,148 @@
+package typeform
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy decides if and when failed requests are retried.
+// Transport errors, 429 and 5xx responses (except 501) are retried;
+// by default only idempotent methods (GET, PUT, DELETE) are retried.
+type RetryPolicy struct {
+	MaxAttempts int           // The maximum number of attempts, including the first one; 0 or 1 disables retries
+	MinBackoff  time.Duration // The base wait before the first retry; doubled on each subsequent retry
+	MaxBackoff  time.Duration // The maximum wait between two attempts, including the waits asked by the API with Retry-After
+
+	// RetryPOST enables retries for POST requests carrying an idempotency key
+	// (see WithIdempotencyKey); POST requests without a key are never retried
+	RetryPOST bool
+}
+
+// DefaultRetryPolicy is a sensible policy for batch jobs
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  250 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *Client) error {
+		if policy.MaxAttempts < 0 {
+			return fmt.Errorf("max attempts is negative: %v", policy.MaxAttempts)
+		}
+		if policy.MinBackoff < 0 || policy.MaxBackoff < 0 {
+			return fmt.Errorf("backoff is negative: min %v, max %v", policy.MinBackoff, policy.MaxBackoff)
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			return fmt.Errorf("max backoff %v is less than min backoff %v", policy.MaxBackoff, policy.MinBackoff)
+		}
+		client.retryPolicy = policy
+		return nil
+	}
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx which makes the request it is used
+// for carry the provided idempotency key
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// allowsMethod tells whether a request with the provided method and headers can be retried
+func (policy RetryPolicy) allowsMethod(method string, headers http.Header) bool {
+	if policy.MaxAttempts <= 1 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return policy.RetryPOST && headers.Get(IdempotencyKeyHeader) != ""
+	}
+	return false
+}
+
+// backoff returns how long to wait after the provided (failed) attempt;
+// the Retry-After header of the response, if any, takes precedence, but is capped to MaxBackoff
+// so that the API can't make the client sleep for hours
+func (policy RetryPolicy) backoff(attempt int, responseHeaders http.Header) time.Duration {
+	if wait, ok := parseRetryAfter(responseHeaders.Get("Retry-After"), time.Now()); ok {
+		if wait > policy.MaxBackoff {
+			wait = policy.MaxBackoff
+		}
+		return wait
+	}
+
+	wait := policy.MinBackoff
+	for i := 1; i < attempt && wait < policy.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > policy.MaxBackoff {
+		wait = policy.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// half of the wait is fixed, the other half is random
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// isRetryableStatus tells whether a response with the provided status code is worth retrying
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || (statusCode >= 500 && statusCode != http.StatusNotImplemented)
+}
+
+// parseRetryAfter parses the value of a Retry-After header,
+// which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the provided duration, or until ctx is done
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
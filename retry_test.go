@@ -0,0 +1,112 @@
+package typeform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFlakyServer(failures int32, status int) (*httptest.Server, *int32) {
+	var attempts int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"error":"temporary","description":"try again"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"abc","idempotency_key":%q}`, r.Header.Get(IdempotencyKeyHeader))
+	}))
+	return testServer, &attempts
+}
+
+func newRetryingClient(t *testing.T, baseURL string, policy RetryPolicy) *Client {
+	retryingClient, err := NewClient(Latest, WithBaseURL(baseURL), WithRetryPolicy(policy))
+	assert.Nil(t, err, "no error should occur")
+	retryingClient.SetAPIToken("token")
+	return retryingClient
+}
+
+func TestRetryIdempotentMethods(t *testing.T) {
+	testServer, attempts := newFlakyServer(2, http.StatusServiceUnavailable)
+	defer testServer.Close()
+
+	retryingClient := newRetryingClient(t, testServer.URL, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	formInfo, err := retryingClient.GetForm("abc")
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, "abc", formInfo.ID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(attempts))
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	testServer, attempts := newFlakyServer(5, http.StatusTooManyRequests)
+	defer testServer.Close()
+
+	retryingClient := newRetryingClient(t, testServer.URL, RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	_, err := retryingClient.GetForm("abc")
+	assert.NotNil(t, err, "the last error should be returned")
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+}
+
+func TestRetryPOST(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryPOST: true}
+
+	testServer, attempts := newFlakyServer(1, http.StatusBadGateway)
+	defer testServer.Close()
+
+	retryingClient := newRetryingClient(t, testServer.URL, policy)
+
+	_, err := retryingClient.CreateForm(Form{Title: "no key"})
+	assert.NotNil(t, err, "POST without idempotency key should not be retried")
+	assert.Equal(t, int32(1), atomic.LoadInt32(attempts))
+
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+	formInfo, err := retryingClient.CreateFormWithContext(ctx, Form{Title: "with key"})
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, "abc", formInfo.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(attempts))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2017, 2, 28, 12, 0, 0, 0, time.UTC)
+
+	wait, ok := parseRetryAfter("3", now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, wait)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestBackoffIsBounded(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt := 1; attempt < 10; attempt++ {
+		wait := policy.backoff(attempt, nil)
+		assert.True(t, wait >= policy.MinBackoff/2 && wait <= policy.MaxBackoff, "attempt %v waited %v", attempt, wait)
+	}
+}
+
+func TestBackoffCapsRetryAfter(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, time.Second, policy.backoff(1, http.Header{"Retry-After": []string{"3600"}}), "the wait asked by the API should be capped")
+	assert.Equal(t, time.Duration(0), policy.backoff(1, http.Header{"Retry-After": []string{"0"}}))
+}
+
+func TestNewClientRetriesByDefault(t *testing.T) {
+	client, err := NewClient(Latest)
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, DefaultRetryPolicy, client.retryPolicy)
+	assert.True(t, client.retryPolicy.allowsMethod(http.MethodGet, http.Header{}), "idempotent methods should be retried by default")
+}
@@ -0,0 +1,332 @@
+package typeform
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRunAborted is returned by RunForm when the input ends before the form is submitted
+var ErrRunAborted = errors.New("input ended before the form was submitted")
+
+// RunForm asks the questions of the form one by one, writing them to out and reading the answers
+// line by line from in, without any network access. It enforces the constraints of the fields, asking again
+// until the answer is valid, follows the logic jumps of the form, and returns the answers as the Data API would.
+// Since the fields of a local form have no IDs, answers are identified by the Ref of their field,
+// or by its path (e.g. "fields[2]") if it has no Ref.
+// If in ends before the form is submitted, the answers given so far are returned with ErrRunAborted.
+func RunForm(form Form, in io.Reader, out io.Writer) (Response, error) {
+	runner := &formRunner{
+		form: form,
+		in:   bufio.NewReader(in),
+		out:  out,
+	}
+
+	token, err := newResponseToken()
+	if err != nil {
+		return Response{}, err
+	}
+	response := Response{
+		ResponseID: token,
+		Token:      token,
+		LandedAt:   time.Now().UTC().Truncate(time.Second),
+		Metadata:   ResponseMetadata{Platform: "other", UserAgent: DefaultUserAgent},
+	}
+
+	fmt.Fprintf(out, "%s\n", form.Title)
+	numbers := questionNumbers(form)
+	answers := map[string]Answer{}
+	for i := 0; i < len(form.Fields); i = form.NextField(i, answers) {
+		answer, answered, err := runner.ask(i, numbers[i])
+		if err != nil {
+			return response, err
+		}
+		if answered {
+			response.Answers = append(response.Answers, answer)
+			if answer.Field.Ref != "" {
+				answers[answer.Field.Ref] = answer
+			}
+		}
+	}
+
+	response.SubmittedAt = time.Now().UTC().Truncate(time.Second)
+	return response, nil
+}
+
+// formRunner is the state of RunForm
+type formRunner struct {
+	form Form
+	in   *bufio.Reader
+	out  io.Writer
+}
+
+// newResponseToken returns a random token, in the format of the tokens of the responses of the API
+func newResponseToken() (string, error) {
+	token := make([]byte, 16)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// questionNumbers returns the number shown before each field: statements are not numbered
+func questionNumbers(form Form) []int {
+	numbers := make([]int, len(form.Fields))
+	number := 0
+	for i, field := range form.Fields {
+		if field.Type != Statement {
+			number++
+			numbers[i] = number
+		}
+	}
+	return numbers
+}
+
+// readLine reads the next line of the input, without the surrounding spaces
+func (runner *formRunner) readLine() (string, error) {
+	fmt.Fprint(runner.out, "> ")
+	line, err := runner.in.ReadString('\n')
+	if err == io.EOF && line == "" {
+		fmt.Fprintln(runner.out)
+		return "", ErrRunAborted
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// ask asks field i until it gets a valid answer; answered is false if the field was skipped
+func (runner *formRunner) ask(i int, number int) (answer Answer, answered bool, err error) {
+	field := runner.form.Fields[i]
+	fmt.Fprintln(runner.out)
+
+	if field.Type == Statement {
+		fmt.Fprintln(runner.out, field.Question)
+		if field.Description != "" {
+			fmt.Fprintln(runner.out, field.Description)
+		}
+		buttonText := field.ButtonText
+		if buttonText == "" {
+			buttonText = "Continue"
+		}
+		fmt.Fprintf(runner.out, "Press Enter to %s\n", strings.ToLower(buttonText))
+		_, err = runner.readLine()
+		return Answer{}, false, err
+	}
+
+	required := ""
+	if field.Required {
+		required = " *"
+	}
+	fmt.Fprintf(runner.out, "%d → %s%s\n", number, field.Question, required)
+	if field.Description != "" {
+		fmt.Fprintln(runner.out, field.Description)
+	}
+	runner.printHint(field)
+
+	for {
+		line, err := runner.readLine()
+		if err != nil {
+			return Answer{}, false, err
+		}
+		if line == "" {
+			if field.Required {
+				fmt.Fprintln(runner.out, "This question is required")
+				continue
+			}
+			return Answer{}, false, nil
+		}
+
+		answer, err := runner.parseAnswer(field, line)
+		if err == ErrRunAborted {
+			return Answer{}, false, err
+		}
+		if err != nil {
+			fmt.Fprintf(runner.out, "Invalid answer: %s\n", err)
+			continue
+		}
+		answer.Field = AnswerField{ID: field.Ref, Type: field.Type, Ref: field.Ref}
+		if field.Ref == "" {
+			answer.Field.ID = fmt.Sprintf("fields[%d]", i)
+		}
+		return answer, true, nil
+	}
+}
+
+// printHint explains how to answer the field
+func (runner *formRunner) printHint(field Field) {
+	switch field.Type {
+	case ShortText, LongText:
+		if field.MaxCharacters > 0 {
+			fmt.Fprintf(runner.out, "(at most %d characters)\n", field.MaxCharacters)
+		}
+	case Number:
+		minValue, hasMin, maxValue, hasMax := field.Bounds()
+		switch {
+		case hasMin && hasMax:
+			fmt.Fprintf(runner.out, "(a number between %d and %d)\n", minValue, maxValue)
+		case hasMin:
+			fmt.Fprintf(runner.out, "(a number of at least %d)\n", minValue)
+		case hasMax:
+			fmt.Fprintf(runner.out, "(a number of at most %d)\n", maxValue)
+		}
+	case Rating, OpinionScale:
+		first, last := stepRange(field)
+		fmt.Fprintf(runner.out, "(%d-%d)\n", first, last)
+	case YesNo:
+		fmt.Fprintln(runner.out, "(y/n)")
+	case Legal:
+		fmt.Fprintln(runner.out, "(y: I accept, n: I don't accept)")
+	case MultipleChoice, PictureChoice, Dropdown:
+		choices := shownChoices(field)
+		for i, choice := range choices {
+			label := choice.Label
+			if label == "" {
+				label = "image " + choice.ImageID
+			}
+			fmt.Fprintf(runner.out, "  %d) %s\n", i+1, label)
+		}
+		if field.AddOtherChoice {
+			fmt.Fprintf(runner.out, "  %d) Other\n", len(choices)+1)
+		}
+		if field.AllowMultipleSelections {
+			fmt.Fprintln(runner.out, "(choose as many as you like, separated by commas)")
+		}
+	}
+}
+
+// parseAnswer parses the answer to the field, and checks it against the constraints of the field with ValidateAnswer
+func (runner *formRunner) parseAnswer(field Field, line string) (Answer, error) {
+	var answer Answer
+	switch field.Type {
+	case ShortText, LongText:
+		answer = Answer{Type: TextAnswer, Text: line}
+
+	case Email:
+		answer = Answer{Type: EmailAnswer, Email: line}
+
+	case Website:
+		answer = Answer{Type: URLAnswer, URL: line}
+
+	case Number, Rating, OpinionScale:
+		number, err := strconv.Atoi(line)
+		if err != nil {
+			return Answer{}, errors.New("not an integer")
+		}
+		answer = Answer{Type: NumberAnswer, Number: &number}
+
+	case YesNo, Legal:
+		var yes bool
+		switch strings.ToLower(line) {
+		case "y", "yes":
+			yes = true
+		case "n", "no":
+			yes = false
+		default:
+			return Answer{}, errors.New("answer y or n")
+		}
+		answer = Answer{Type: BooleanAnswer, Boolean: &yes}
+
+	case MultipleChoice, PictureChoice, Dropdown:
+		var err error
+		answer, err = runner.parseChoices(field, line)
+		if err != nil {
+			return Answer{}, err
+		}
+
+	default:
+		return Answer{Type: TextAnswer, Text: line}, nil
+	}
+
+	err := ValidateAnswer(field, answer)
+	if validationErrors, ok := err.(ValidationErrors); ok {
+		// the answer is checked as it's typed, so the path of the violation is not relevant
+		return Answer{}, errors.New(validationErrors[0].Message)
+	}
+	return answer, err
+}
+
+// parseChoices parses the selection of a choice field, given as the labels or numbers of the choices;
+// a label matches before a number, so that a choice labeled "2" can be selected.
+// If the "Other" choice is selected, its text is read from the next line
+func (runner *formRunner) parseChoices(field Field, line string) (Answer, error) {
+	choices := shownChoices(field)
+
+	var labels []string
+	selected := map[int]bool{}
+	other := false
+	for _, selection := range strings.Split(line, ",") {
+		selection = strings.TrimSpace(selection)
+		index := choiceIndex(choices, selection)
+		if index == -1 {
+			if number, err := strconv.Atoi(selection); err == nil {
+				index = number - 1
+			} else if field.AddOtherChoice && strings.EqualFold(selection, "other") {
+				index = len(choices)
+			}
+		}
+
+		switch {
+		case index >= 0 && index < len(choices):
+			// choices are told apart by index, as picture choices can have no label
+			if !selected[index] {
+				selected[index] = true
+				labels = append(labels, choices[index].Label)
+			}
+		case index == len(choices) && field.AddOtherChoice:
+			other = true
+		default:
+			return Answer{}, fmt.Errorf("no choice %q", selection)
+		}
+	}
+	if (len(labels) > 1 || other && len(labels) > 0) && !field.AllowMultipleSelections {
+		return Answer{}, errors.New("choose only one")
+	}
+
+	otherText := ""
+	if other {
+		fmt.Fprintln(runner.out, "Other:")
+		for otherText == "" {
+			var err error
+			otherText, err = runner.readLine()
+			if err != nil {
+				return Answer{}, err
+			}
+		}
+	}
+
+	if field.AllowMultipleSelections {
+		return Answer{Type: ChoicesAnswer, Choices: &SelectedChoices{Labels: labels, Other: otherText}}, nil
+	}
+	if other {
+		return Answer{Type: ChoiceAnswer, Choice: &SelectedChoice{Other: otherText}}, nil
+	}
+	return Answer{Type: ChoiceAnswer, Choice: &SelectedChoice{Label: labels[0]}}, nil
+}
+
+// choiceIndex returns the index of the choice labeled selection, preferring an exact match to
+// a case-insensitive one, or -1 if no label matches
+func choiceIndex(choices []Choice, selection string) int {
+	if selection == "" {
+		return -1
+	}
+	for i, choice := range choices {
+		if choice.Label == selection {
+			return i
+		}
+	}
+	for i, choice := range choices {
+		if strings.EqualFold(choice.Label, selection) {
+			return i
+		}
+	}
+	return -1
+}
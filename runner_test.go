@@ -0,0 +1,119 @@
+package typeform
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunForm(t *testing.T) {
+	form, err := NewForm("Survey").
+		ShortText("Name?", Ref("name"), Required(), MaxChars(5)).
+		YesNo("Do you have a website?", Ref("has_website")).
+		Website("Which one?", Ref("website")).
+		Email("Email?", Ref("email")).
+		Number("Age?", Ref("age"), MinValue(18), MaxValue(99)).
+		Rating("Rate us", Ref("rating"), Steps(3)).
+		MultipleChoice("What could we do better?", []string{"Prices", "Support"}, Ref("better"), AllowMultipleSelections(), AddOtherChoice()).
+		Dropdown("Country", []string{"Italy", "France"}, Ref("country"), AlphabeticalOrder()).
+		Statement("Thanks!").
+		JumpIf("has_website", false, "email").
+		Build()
+	assert.Nil(t, err, "no error should occur")
+
+	input := strings.Join([]string{
+		"",                 // required: asked again
+		"Johnny",           // too long: asked again
+		"John",             // name
+		"n",                // has_website: jumps over website
+		"not an email",     // asked again
+		"john@example.com", // email
+		"17",               // too low: asked again
+		"30",               // age
+		"4",                // out of the steps: asked again
+		"3",                // rating
+		"support, 3",       // better: a label and the number of "Other"
+		"Delivery times",   // the text of "Other"
+		"1",                // country: the first of the sorted choices
+		"",                 // statement
+	}, "\n") + "\n"
+	var output bytes.Buffer
+	response, err := RunForm(form, strings.NewReader(input), &output)
+	assert.Nil(t, err, "no error should occur")
+
+	assert.True(t, response.Completed())
+	assert.Len(t, response.Token, 32)
+	assert.Contains(t, output.String(), "This question is required")
+	assert.Contains(t, output.String(), "Invalid answer: must be at most 5 characters long")
+	assert.Contains(t, output.String(), "Invalid answer: must be at least 18")
+	assert.Contains(t, output.String(), "Invalid answer: must be between 1 and 3")
+
+	answers := response.AnswersByRef()
+	assert.Len(t, response.Answers, 7)
+	assert.NotContains(t, answers, "website", "the website should have been skipped")
+	assert.Equal(t, "John", answers["name"].Value())
+	assert.Equal(t, false, answers["has_website"].Value())
+	assert.Equal(t, "john@example.com", answers["email"].Value())
+	assert.Equal(t, 30, answers["age"].Value())
+	assert.Equal(t, 3, answers["rating"].Value())
+	assert.Equal(t, SelectedChoices{Labels: []string{"Support"}, Other: "Delivery times"}, answers["better"].Value())
+	assert.Equal(t, SelectedChoice{Label: "France"}, answers["country"].Value())
+
+	// the response should have the shape of the responses of the Data API
+	data, err := json.Marshal(response)
+	assert.Nil(t, err, "no error should occur")
+	var decoded Response
+	assert.Nil(t, json.Unmarshal(data, &decoded), "no error should occur")
+	assert.Equal(t, response.Answers, decoded.Answers)
+	assert.Contains(t, string(data), `{"field":{"id":"age","type":"number","ref":"age"},"type":"number","number":30}`)
+}
+
+func TestRunFormAborted(t *testing.T) {
+	form, err := NewForm("Survey").
+		ShortText("Name?", Ref("name")).
+		MultipleChoice("Color?", []string{"Red", "Blue"}, Ref("color")).
+		Build()
+	assert.Nil(t, err, "no error should occur")
+
+	response, err := RunForm(form, strings.NewReader("John\nred, blue\n"), &bytes.Buffer{})
+	assert.Equal(t, ErrRunAborted, err, "the input should end before the form is submitted")
+	assert.False(t, response.Completed())
+	assert.Len(t, response.Answers, 1, "the answers given so far should be returned")
+}
+
+func TestRunFormChoices(t *testing.T) {
+	form := Form{
+		Title: "Survey",
+		Fields: []Field{
+			{Type: MultipleChoice, Ref: "numbers", Question: "Pick", Choices: []Choice{{Label: "10"}, {Label: "2"}, {Label: "1"}}, AllowMultipleSelections: true},
+			{Type: PictureChoice, Ref: "pictures", Question: "Pick", Choices: []Choice{{ImageID: "a"}, {ImageID: "b"}}, AllowMultipleSelections: true},
+		},
+	}
+
+	input := strings.Join([]string{
+		"2, 1, 3", // labels match before numbers, and the third choice is labeled "1"
+		"1, 2",    // unlabeled pictures are told apart by number
+	}, "\n") + "\n"
+	response, err := RunForm(form, strings.NewReader(input), &bytes.Buffer{})
+	assert.Nil(t, err, "no error should occur")
+
+	answers := response.AnswersByRef()
+	assert.Equal(t, SelectedChoices{Labels: []string{"2", "1"}}, answers["numbers"].Value())
+	assert.Equal(t, SelectedChoices{Labels: []string{"", ""}}, answers["pictures"].Value())
+}
+
+func TestRunFormZeroBound(t *testing.T) {
+	var field Field
+	assert.Nil(t, json.Unmarshal([]byte(`{"type":"number","ref":"count","question":"How many?","min_value":0}`), &field))
+	form := Form{Title: "Survey", Fields: []Field{field}}
+
+	var output bytes.Buffer
+	response, err := RunForm(form, strings.NewReader("-1\n0\n"), &output)
+	assert.Nil(t, err, "no error should occur")
+	assert.Contains(t, output.String(), "(a number of at least 0)")
+	assert.Contains(t, output.String(), "Invalid answer: must be at least 0")
+	assert.Equal(t, 0, response.AnswersByRef()["count"].Value())
+}
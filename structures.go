@@ -1,7 +1,9 @@
 package typeform
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -12,8 +14,13 @@ type Client struct {
 	config     struct {
 		APIKey string
 	}
-	apiVersion APIVersion
-	mu         *sync.RWMutex
+	apiVersion  APIVersion
+	baseURL     string
+	userAgent   string
+	headers     http.Header
+	retryPolicy RetryPolicy
+	rateLimiter RateLimiter
+	mu          *sync.RWMutex
 }
 
 //
@@ -68,7 +75,7 @@ func (ct *Timestamp) UnmarshalJSON(b []byte) error {
 
 // MarshalJSON marshals a Timestamp to JSON
 func (ct *Timestamp) MarshalJSON() ([]byte, error) {
-	return []byte(ct.Time.Format(TimestampFormat)), nil
+	return []byte(strconv.Quote(ct.Time.Format(TimestampFormat))), nil
 }
 
 // Form is the struct that represents a form
@@ -120,9 +127,17 @@ type Field struct {
 	AlphabeticalOrder bool `json:"alphabetical_order,omitempty"` // If the choices should be sorted in alphabetic order
 
 	// number
+	// A zero MinValue or MaxValue means no bound, unless it was set explicitly: decoded from JSON,
+	// or set by the MinValue and MaxValue options or by NumberField. Such a zero bound is marshaled and enforced,
+	// so the field differs from a Field literal with the same values, e.g. for reflect.DeepEqual;
+	// a literal can't set a zero bound, so use NumberField.ToField instead. See Field.Bounds
 	MinValue int `json:"min_value,omitempty"` // The minimum value your respondent can answer
 	MaxValue int `json:"max_value,omitempty"` // The maximum value your respondent can answer
 
+	// whether a zero MinValue or MaxValue was set explicitly
+	minValueSet bool
+	maxValueSet bool
+
 	// rating
 	Steps int    `json:"steps,omitempty"` // The number of steps the user can chose. Is limited to a value between 1 - 10
 	Shape string `json:"shape,omitempty"` // The icon to use for the steps. Use the list in Typeform.com to get the icon you want. "Stars" in Typeform.com would be used as "stars".
@@ -208,6 +223,8 @@ type FormInfo struct {
 	Title   string     `json:"title"`
 	URLs    []URL      `json:"urls"`
 	Version APIVersion `json:"version"`
+
+	rawFields json.RawMessage // The JSON of the fields, for FieldSpecs
 }
 
 // Link is info about a link
@@ -1,14 +1,20 @@
 package typeform
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 )
 
 // BaseInfo handles the endpoint used to get info about the API
 func (client *Client) BaseInfo() (*BaseInfo, error) {
+	return client.BaseInfoWithContext(context.Background())
+}
+
+// BaseInfoWithContext is like BaseInfo, but the request is bound to ctx
+func (client *Client) BaseInfoWithContext(ctx context.Context) (*BaseInfo, error) {
 
 	path := fmt.Sprintf("/%v/", client.apiVersion)
 	method := http.MethodGet
@@ -17,13 +23,8 @@ func (client *Client) BaseInfo() (*BaseInfo, error) {
 	queryParameters := url.Values{}
 	var bodyPayload interface{}
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var baseInfo BaseInfo
-	err = json.Unmarshal(response, &baseInfo)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &baseInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +33,11 @@ func (client *Client) BaseInfo() (*BaseInfo, error) {
 
 // CreateForm handles the endpoint used to get create a new form from the provided model
 func (client *Client) CreateForm(newForm Form) (*FormInfo, error) {
+	return client.CreateFormWithContext(context.Background(), newForm)
+}
+
+// CreateFormWithContext is like CreateForm, but the request is bound to ctx
+func (client *Client) CreateFormWithContext(ctx context.Context, newForm Form) (*FormInfo, error) {
 	path := fmt.Sprintf("/%v/forms", client.apiVersion)
 	method := http.MethodPost
 
@@ -40,21 +46,45 @@ func (client *Client) CreateForm(newForm Form) (*FormInfo, error) {
 	var bodyPayload interface{}
 	bodyPayload = newForm
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
+	var formInfo FormInfo
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &formInfo)
 	if err != nil {
-		return nil, err
+		return nil, newFormValidationError(newForm, err)
 	}
+	return &formInfo, nil
+}
+
+// CreateTypedForm handles the endpoint used to create a new form from the provided model;
+// unlike CreateForm, zero values of the options of the fields are sent when explicitly set
+func (client *Client) CreateTypedForm(newForm TypedForm) (*FormInfo, error) {
+	return client.CreateTypedFormWithContext(context.Background(), newForm)
+}
+
+// CreateTypedFormWithContext is like CreateTypedForm, but the request is bound to ctx
+func (client *Client) CreateTypedFormWithContext(ctx context.Context, newForm TypedForm) (*FormInfo, error) {
+	path := fmt.Sprintf("/%v/forms", client.apiVersion)
+	method := http.MethodPost
+
+	headers := http.Header{}
+	queryParameters := url.Values{}
+	var bodyPayload interface{}
+	bodyPayload = newForm
 
 	var formInfo FormInfo
-	err = json.Unmarshal(response, &formInfo)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &formInfo)
 	if err != nil {
-		return nil, err
+		return nil, newFormValidationError(newForm.ToForm(), err)
 	}
 	return &formInfo, nil
 }
 
 // GetForm handles the endpoint used to fetch a form by ID
 func (client *Client) GetForm(formID string) (*FormInfo, error) {
+	return client.GetFormWithContext(context.Background(), formID)
+}
+
+// GetFormWithContext is like GetForm, but the request is bound to ctx
+func (client *Client) GetFormWithContext(ctx context.Context, formID string) (*FormInfo, error) {
 
 	path := fmt.Sprintf("/%v/forms/%v", client.apiVersion, formID)
 	method := http.MethodGet
@@ -63,13 +93,8 @@ func (client *Client) GetForm(formID string) (*FormInfo, error) {
 	queryParameters := url.Values{}
 	var bodyPayload interface{}
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var formInfo FormInfo
-	err = json.Unmarshal(response, &formInfo)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &formInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +104,11 @@ func (client *Client) GetForm(formID string) (*FormInfo, error) {
 // CreateImage handles the endpoint used to upload an image that
 // will be then available for use in the forms as "Picture Choices"
 func (client *Client) CreateImage(imageURL string) (*NewImage, error) {
+	return client.CreateImageWithContext(context.Background(), imageURL)
+}
+
+// CreateImageWithContext is like CreateImage, but the request is bound to ctx
+func (client *Client) CreateImageWithContext(ctx context.Context, imageURL string) (*NewImage, error) {
 
 	path := fmt.Sprintf("/%v/images", client.apiVersion)
 	method := http.MethodPost
@@ -93,13 +123,8 @@ func (client *Client) CreateImage(imageURL string) (*NewImage, error) {
 	newImage.URL = imageURL
 	bodyPayload = newImage
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var newImageResponse NewImage
-	err = json.Unmarshal(response, &newImageResponse)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &newImageResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +133,11 @@ func (client *Client) CreateImage(imageURL string) (*NewImage, error) {
 
 // GetImage handles the endpoint used to get an image by ID
 func (client *Client) GetImage(imageID string) (*ImageInfo, error) {
+	return client.GetImageWithContext(context.Background(), imageID)
+}
+
+// GetImageWithContext is like GetImage, but the request is bound to ctx
+func (client *Client) GetImageWithContext(ctx context.Context, imageID string) (*ImageInfo, error) {
 
 	path := fmt.Sprintf("/%v/images/%v", client.apiVersion, imageID)
 	method := http.MethodGet
@@ -116,13 +146,8 @@ func (client *Client) GetImage(imageID string) (*ImageInfo, error) {
 	queryParameters := url.Values{}
 	var bodyPayload interface{}
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var imageInfo ImageInfo
-	err = json.Unmarshal(response, &imageInfo)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &imageInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +157,11 @@ func (client *Client) GetImage(imageID string) (*ImageInfo, error) {
 // CreateDesign handles the endpoint used to create a design that will
 // be available to be used to style forms
 func (client *Client) CreateDesign(newDesign Design) (*DesignInfo, error) {
+	return client.CreateDesignWithContext(context.Background(), newDesign)
+}
+
+// CreateDesignWithContext is like CreateDesign, but the request is bound to ctx
+func (client *Client) CreateDesignWithContext(ctx context.Context, newDesign Design) (*DesignInfo, error) {
 	path := fmt.Sprintf("/%v/designs", client.apiVersion)
 	method := http.MethodPost
 
@@ -140,13 +170,8 @@ func (client *Client) CreateDesign(newDesign Design) (*DesignInfo, error) {
 	var bodyPayload interface{}
 	bodyPayload = newDesign
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var designInfo DesignInfo
-	err = json.Unmarshal(response, &designInfo)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &designInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +180,11 @@ func (client *Client) CreateDesign(newDesign Design) (*DesignInfo, error) {
 
 // GetDesign handles the endpoint used to get a design
 func (client *Client) GetDesign(designID string) (*DesignInfo, error) {
+	return client.GetDesignWithContext(context.Background(), designID)
+}
+
+// GetDesignWithContext is like GetDesign, but the request is bound to ctx
+func (client *Client) GetDesignWithContext(ctx context.Context, designID string) (*DesignInfo, error) {
 
 	path := fmt.Sprintf("/%v/designs/%v", client.apiVersion, designID)
 	method := http.MethodGet
@@ -163,13 +193,8 @@ func (client *Client) GetDesign(designID string) (*DesignInfo, error) {
 	queryParameters := url.Values{}
 	var bodyPayload interface{}
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var designInfo DesignInfo
-	err = json.Unmarshal(response, &designInfo)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &designInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +203,11 @@ func (client *Client) GetDesign(designID string) (*DesignInfo, error) {
 
 // CreateURL handles the endpoint used to create a new URL linking to a typeform
 func (client *Client) CreateURL(formID string) (*URLInfo, error) {
+	return client.CreateURLWithContext(context.Background(), formID)
+}
+
+// CreateURLWithContext is like CreateURL, but the request is bound to ctx
+func (client *Client) CreateURLWithContext(ctx context.Context, formID string) (*URLInfo, error) {
 
 	path := fmt.Sprintf("/%v/urls", client.apiVersion)
 	method := http.MethodPost
@@ -192,13 +222,8 @@ func (client *Client) CreateURL(formID string) (*URLInfo, error) {
 	newURL.FormID = formID
 	bodyPayload = newURL
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var newURLResponse URLInfo
-	err = json.Unmarshal(response, &newURLResponse)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &newURLResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +232,11 @@ func (client *Client) CreateURL(formID string) (*URLInfo, error) {
 
 // GetURL handles the endpoint used to get the typeform a URL links to
 func (client *Client) GetURL(URLID string) (*URLInfo, error) {
+	return client.GetURLWithContext(context.Background(), URLID)
+}
+
+// GetURLWithContext is like GetURL, but the request is bound to ctx
+func (client *Client) GetURLWithContext(ctx context.Context, URLID string) (*URLInfo, error) {
 
 	path := fmt.Sprintf("/%v/urls/%v", client.apiVersion, URLID)
 	method := http.MethodGet
@@ -215,13 +245,8 @@ func (client *Client) GetURL(URLID string) (*URLInfo, error) {
 	queryParameters := url.Values{}
 	var bodyPayload interface{}
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var URLInfoResponse URLInfo
-	err = json.Unmarshal(response, &URLInfoResponse)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &URLInfoResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +255,11 @@ func (client *Client) GetURL(URLID string) (*URLInfo, error) {
 
 // ModifyURL handles the endpoint used to change an existing URL to link to a different typeform
 func (client *Client) ModifyURL(URLID string, formID string) (*URLInfo, error) {
+	return client.ModifyURLWithContext(context.Background(), URLID, formID)
+}
+
+// ModifyURLWithContext is like ModifyURL, but the request is bound to ctx
+func (client *Client) ModifyURLWithContext(ctx context.Context, URLID string, formID string) (*URLInfo, error) {
 
 	path := fmt.Sprintf("/%v/urls/%v", client.apiVersion, URLID)
 	method := http.MethodPut
@@ -244,13 +274,8 @@ func (client *Client) ModifyURL(URLID string, formID string) (*URLInfo, error) {
 	newURL.FormID = formID
 	bodyPayload = newURL
 
-	response, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
-	if err != nil {
-		return nil, err
-	}
-
 	var newURLResponse URLInfo
-	err = json.Unmarshal(response, &newURLResponse)
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &newURLResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -259,6 +284,11 @@ func (client *Client) ModifyURL(URLID string, formID string) (*URLInfo, error) {
 
 // DeleteURL handles the endpoint used to delete a URL that links to a typeform
 func (client *Client) DeleteURL(URLID string) error {
+	return client.DeleteURLWithContext(context.Background(), URLID)
+}
+
+// DeleteURLWithContext is like DeleteURL, but the request is bound to ctx
+func (client *Client) DeleteURLWithContext(ctx context.Context, URLID string) error {
 
 	path := fmt.Sprintf("/%v/urls/%v", client.apiVersion, URLID)
 	method := http.MethodDelete
@@ -267,10 +297,47 @@ func (client *Client) DeleteURL(URLID string) error {
 	queryParameters := url.Values{}
 	var bodyPayload interface{}
 
-	_, _, err := client.fetchAndReturnPage(path, method, headers, queryParameters, bodyPayload)
+	_, _, err := client.fetchAndReturnPage(ctx, path, method, headers, queryParameters, bodyPayload)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// GetResponses handles the endpoint used to get the responses submitted to a form
+func (client *Client) GetResponses(formID string, query ResponsesQuery) (*Responses, error) {
+	return client.GetResponsesWithContext(context.Background(), formID, query)
+}
+
+// GetResponsesWithContext is like GetResponses, but the request is bound to ctx
+func (client *Client) GetResponsesWithContext(ctx context.Context, formID string, query ResponsesQuery) (*Responses, error) {
+
+	path := fmt.Sprintf("/%v/forms/%v/responses", client.apiVersion, formID)
+	method := http.MethodGet
+
+	headers := http.Header{}
+	queryParameters := query.values()
+	var bodyPayload interface{}
+
+	var responses Responses
+	_, err := client.fetchAndDecode(ctx, path, method, headers, queryParameters, bodyPayload, &responses)
+	if err != nil {
+		return nil, err
+	}
+	return &responses, nil
+}
+
+// GetResponsesRaw is like GetResponsesWithContext, but returns the JSON body of the response
+// without decoding it, e.g. to export it; the caller must close it
+func (client *Client) GetResponsesRaw(ctx context.Context, formID string, query ResponsesQuery) (io.ReadCloser, error) {
+
+	path := fmt.Sprintf("/%v/forms/%v/responses", client.apiVersion, formID)
+	method := http.MethodGet
+
+	responseBody, _, err := client.RawRequest(ctx, method, path, query.values(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return responseBody, nil
+}
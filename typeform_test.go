@@ -0,0 +1,327 @@
+package typeform_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+	"github.com/gagliardetto/go-ask-awesomely/typeformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// client makes the requests of the tests of the endpoints: to the real API if
+// the TYPEFORM_TEST_API_KEY environment variable is set, otherwise to a typeformtest.Server
+var client *typeform.Client
+
+func TestMain(m *testing.M) {
+	var err error
+	if key := os.Getenv("TYPEFORM_TEST_API_KEY"); key != "" {
+		client, err = typeform.NewClient(typeform.Latest)
+		if err == nil {
+			err = client.SetAPIToken(key)
+		}
+	} else {
+		server := typeformtest.NewServer()
+		defer server.Close()
+		client, err = server.NewClient()
+	}
+	if err != nil {
+		fmt.Println("client setup error: ", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	if code != 0 {
+		os.Exit(code)
+	}
+}
+
+func beautify(object interface{}) string {
+	out, err := json.MarshalIndent(object, "", "\t")
+	if err != nil {
+		fmt.Println(err)
+		return ""
+	}
+	return string(out)
+}
+
+func TestBaseInfo(t *testing.T) {
+	baseInfo, err := client.BaseInfo()
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, baseInfo, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nAPI info: %#v\n", baseInfo)
+}
+
+func TestCreateForm(t *testing.T) {
+	newForm := typeform.Form{
+		Title:    "My amazing new form",
+		Branding: true,
+		//Tags:             []string{},
+		//DesignID:         "<design ID>",
+		//WebhookSubmitURL: "<webhook submit URL>",
+		//URLIDs:           []string{},
+
+		LogicJumps: []typeform.LogicJump{
+			typeform.LogicJump{
+				From: "decisive-question",
+				To:   "jump-here",
+				If:   true,
+			},
+		},
+
+		Fields: []typeform.Field{
+			typeform.Field{
+				Type:          typeform.ShortText,
+				Question:      "What are your favorite 3 characters?",
+				Tags:          []string{"some-tag"},
+				MaxCharacters: 3,
+			},
+
+			typeform.Field{
+				Type:          typeform.LongText,
+				Question:      "what is the story of your life?",
+				Tags:          []string{"some-tag"},
+				MaxCharacters: 3000,
+			},
+
+			typeform.Field{
+				Type:                    typeform.MultipleChoice,
+				Question:                "Please select a few choices",
+				Description:             "some description",
+				AllowMultipleSelections: true,
+				Randomize:               false,
+				VerticalAlignment:       false,
+				AddOtherChoice:          true,
+				Tags:                    []string{"some-tag"},
+				Choices: []typeform.Choice{
+					typeform.Choice{
+						Label: "this",
+					},
+					typeform.Choice{
+						Label: "that",
+					},
+					typeform.Choice{
+						Label: "third",
+					},
+				},
+			},
+
+			/*
+			   typeform.Field{
+			       Type:                    typeform.PictureChoice,
+			       Question:                "Choose images",
+			       Description:             "some description",
+			       ShowLabels:              true,
+			       Supersize:               true,
+			       AllowMultipleSelections: true,
+			       Randomize:               false,
+			       AddOtherChoice:          true,
+			       Tags:                    []string{"some-tag"},
+			       Required:                true,
+			       Choices: []typeform.Choice{
+			           typeform.Choice{
+			               ImageID: "HNdAk47LS",
+			               Label:   "this",
+			           },
+			           typeform.Choice{
+			               ImageID: "L2DsjN8JS",
+			               Label:   "that",
+			           },
+			           typeform.Choice{
+			               ImageID: "DLs2d43NS",
+			               Label:   "third",
+			           },
+			       },
+			   },
+			*/
+
+			typeform.Field{
+				Type:       typeform.Statement,
+				Question:   "This is a statement",
+				Tags:       []string{"some-tag"},
+				ButtonText: "Ok",
+				HideMarks:  false,
+			},
+
+			typeform.Field{
+				Type:     typeform.Dropdown,
+				Question: "Choose from dropdown",
+				Tags:     []string{"some-tag"},
+				Choices: []typeform.Choice{
+					typeform.Choice{
+						Label: "Europe",
+					},
+					typeform.Choice{
+						Label: "Asia",
+					},
+					typeform.Choice{
+						Label: "USA",
+					},
+				},
+			},
+
+			typeform.Field{
+				Type:     typeform.YesNo,
+				Question: "Do you wanna jump?",
+				Tags:     []string{"some-tag"},
+				Ref:      "decisive-question",
+				Required: true,
+			},
+
+			typeform.Field{
+				Type:        typeform.Number,
+				Question:    "How many cats do you have?",
+				Description: "some description",
+				Tags:        []string{"some-tag"},
+				MinValue:    0,
+				MaxValue:    99999,
+			},
+
+			typeform.Field{
+				Type:        typeform.Rating,
+				Question:    "Rate",
+				Description: "You probably jumped here from yes/no question",
+				Tags:        []string{"some-tag"},
+				Ref:         "jump-here",
+				Steps:       5,
+				Shape:       "star", // Alternatives: "star", "heart", "user", "up", "crown", "cat", "dog", "circle", "flag", "droplet", "tick", "lightbulb", "trophy", "cloud", "thunderbolt", "pencil", "skull"
+			},
+
+			typeform.Field{
+				Type:        typeform.OpinionScale,
+				Question:    "Opinion scale",
+				Description: "some description",
+				Tags:        []string{"some-tag"},
+				Labels: &typeform.Labels{
+					Left:   "Forms suck",
+					Center: "Who cares",
+					Right:  "I love you",
+				},
+			},
+
+			typeform.Field{
+				Type:        typeform.Email,
+				Question:    "What is your email?",
+				Description: "some description",
+				Tags:        []string{"some-tag"},
+				Required:    true,
+			},
+
+			typeform.Field{
+				Type:        typeform.Website,
+				Question:    "What is your website?",
+				Description: "some description",
+				Tags:        []string{"some-tag"},
+				Required:    false,
+			},
+
+			typeform.Field{
+				Type:        typeform.Legal,
+				Question:    "Do you agree to our terms?",
+				Description: "some description",
+				Tags:        []string{"some-tag"},
+				Required:    true,
+			},
+		},
+	}
+
+	resp, err := client.CreateForm(newForm)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, resp, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nNew form: %v\n", beautify(resp))
+
+	_TestGetForm(t, resp.ID)
+	_TestCreateURL(t, resp.ID)
+}
+
+func _TestGetForm(t *testing.T, formID string) {
+	formInfo, err := client.GetForm(formID)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, formInfo, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nForm info: %#v\n", formInfo)
+}
+
+func TestCreateImage(t *testing.T) {
+	newImage, err := client.CreateImage("https://www.google.it/images/branding/googlelogo/1x/googlelogo_color_272x92dp.png")
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, newImage, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nNew image info: %#v\n", newImage)
+
+	_TestGetImage(t, newImage.ID)
+}
+
+func _TestGetImage(t *testing.T, imageID string) {
+	imageInfo, err := client.GetImage(imageID)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, imageInfo, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nImage info: %#v\n", imageInfo)
+}
+
+func TestCreateDesign(t *testing.T) {
+	newDesign := typeform.Design{
+		Colors: typeform.Colors{
+			Question:   "#3D3D3D",
+			Button:     "#4FB0AE",
+			Answer:     "#4FB0AE",
+			Background: "#FFFFFF",
+		},
+		Font: "Source Sans Pro",
+	}
+
+	newDesignInfo, err := client.CreateDesign(newDesign)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, newDesignInfo, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nNew design info: %#v\n", newDesignInfo)
+
+	_TestGetDesign(t, newDesignInfo.ID)
+}
+
+func _TestGetDesign(t *testing.T, designID string) {
+	designInfo, err := client.GetDesign(designID)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, designInfo, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nDesign info: %#v\n", designInfo)
+}
+
+func _TestCreateURL(t *testing.T, formID string) {
+	newFormURL, err := client.CreateURL(formID)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, newFormURL, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nNew form URL info: %#v\n", newFormURL)
+
+	_TestGetURL(t, newFormURL.ID)
+	_TestModifyURL(t, newFormURL.ID, formID)
+}
+
+func _TestGetURL(t *testing.T, URLID string) {
+	URLInfo, err := client.GetURL(URLID)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, URLInfo, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nURL info: %#v\n", URLInfo)
+}
+
+func _TestModifyURL(t *testing.T, URLID, formID string) {
+	modifiedURLInfo, err := client.ModifyURL(URLID, formID)
+	assert.Nil(t, err, "no error should occur")
+	assert.NotNil(t, modifiedURLInfo, "returned response object pointer should not be nil")
+
+	fmt.Printf("\nModified URL info: %#v\n", modifiedURLInfo)
+
+	_TestDeleteURL(t, modifiedURLInfo.ID)
+}
+
+func _TestDeleteURL(t *testing.T, URLID string) {
+	err := client.DeleteURL(URLID)
+	assert.Nil(t, err, "no error should occur")
+}
@@ -0,0 +1,277 @@
+package typeformtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces the values of the redacted headers in a cassette
+const RedactedValue = "REDACTED"
+
+// redactedHeaders are the headers whose values are never written to a cassette
+var redactedHeaders = []string{"X-API-TOKEN", "Authorization"}
+
+// Cassette is a list of recorded request/response pairs
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request/response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"` // Encoded, with the keys sorted
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response; its body is stored decompressed
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// LoadCassette reads a cassette from a file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	err = json.Unmarshal(data, &cassette)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to a file
+func (cassette *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// readRequestBody reads the body of a request, and replaces it so that it can be sent
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func newRecordedRequest(request *http.Request, body []byte) RecordedRequest {
+	header := request.Header.Clone()
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, RedactedValue)
+		}
+	}
+	return RecordedRequest{
+		Method: request.Method,
+		Path:   request.URL.Path,
+		Query:  request.URL.Query().Encode(),
+		Header: header,
+		Body:   string(body),
+	}
+}
+
+// matches reports whether the recorded request has the same method, path, query and body as request
+func (recorded RecordedRequest) matches(request RecordedRequest) bool {
+	return recorded.Method == request.Method &&
+		recorded.Path == request.Path &&
+		recorded.Query == request.Query &&
+		recorded.Body == request.Body
+}
+
+func (recorded RecordedRequest) String() string {
+	target := recorded.Path
+	if recorded.Query != "" {
+		target += "?" + recorded.Query
+	}
+	return fmt.Sprintf("%s %s %q", recorded.Method, target, recorded.Body)
+}
+
+// Recorder is an http.RoundTripper recording the requests made through it, and their responses, to a cassette
+//
+//	recorder := typeformtest.NewRecorder(nil)
+//	client, err := typeform.NewClient(typeform.Latest, typeform.WithTransport(recorder))
+//	...
+//	err = recorder.Save("testdata/create_form.json")
+type Recorder struct {
+	transport http.RoundTripper
+	mu        sync.Mutex
+	cassette  Cassette
+}
+
+// NewRecorder creates a new Recorder sending the requests with transport; nil uses http.DefaultTransport
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport}
+}
+
+// RoundTrip sends the request, and records it with its response
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := recorder.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	header := response.Header.Clone()
+	if strings.Contains(header.Get("Content-Encoding"), "gzip") {
+		responseBody, err = gunzip(responseBody)
+		if err != nil {
+			return nil, err
+		}
+		header.Del("Content-Encoding")
+		header.Set("Content-Length", strconv.Itoa(len(responseBody)))
+	}
+
+	recorder.mu.Lock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, Interaction{
+		Request: newRecordedRequest(request, requestBody),
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     header,
+			Body:       string(responseBody),
+		},
+	})
+	recorder.mu.Unlock()
+
+	response.Header = header
+	response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+	response.ContentLength = int64(len(responseBody))
+	response.Uncompressed = true
+	return response, nil
+}
+
+// Cassette returns the interactions recorded so far
+func (recorder *Recorder) Cassette() *Cassette {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), recorder.cassette.Interactions...)}
+}
+
+// Save writes the interactions recorded so far to a cassette file
+func (recorder *Recorder) Save(path string) error {
+	return recorder.Cassette().Save(path)
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// Replayer is an http.RoundTripper answering the requests with the responses recorded in a cassette,
+// without network access; each interaction is replayed once, and a request matching none fails
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer creates a new Replayer of the interactions of cassette
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// LoadReplayer creates a new Replayer of the interactions of the cassette in a file
+func LoadReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(cassette), nil
+}
+
+// RoundTrip answers the request with the response of the first unused interaction matching
+// its method, path, query and body
+func (replayer *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	recorded := newRecordedRequest(request, body)
+
+	replayer.mu.Lock()
+	defer replayer.mu.Unlock()
+
+	for i, interaction := range replayer.interactions {
+		if replayer.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		replayer.used[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+		}, nil
+	}
+	return nil, fmt.Errorf("typeformtest: no recorded interaction matches %s", recorded)
+}
+
+// Unused returns the requests of the interactions that have not been replayed yet
+func (replayer *Replayer) Unused() []RecordedRequest {
+	replayer.mu.Lock()
+	defer replayer.mu.Unlock()
+
+	unused := []RecordedRequest{}
+	for i, interaction := range replayer.interactions {
+		if !replayer.used[i] {
+			unused = append(unused, interaction.Request)
+		}
+	}
+	return unused
+}
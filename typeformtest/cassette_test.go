@@ -0,0 +1,62 @@
+package typeformtest_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+	"github.com/gagliardetto/go-ask-awesomely/typeformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+	form, err := typeform.NewForm("Recorded").YesNo("Ok?").Build()
+	assert.Nil(t, err, "no error should occur")
+
+	// record against a live server
+	server := typeformtest.NewServer()
+	recorder := typeformtest.NewRecorder(nil)
+	client, err := server.NewClient(typeform.WithTransport(recorder))
+	assert.Nil(t, err, "no error should occur")
+
+	recorded, err := client.CreateForm(form)
+	assert.Nil(t, err, "no error should occur")
+	_, err = client.GetDesign("missing")
+	assert.True(t, typeform.IsNotFound(err))
+	assert.Nil(t, recorder.Save(cassettePath))
+	server.Close()
+
+	data, err := ioutil.ReadFile(cassettePath)
+	assert.Nil(t, err, "no error should occur")
+	assert.False(t, strings.Contains(string(data), server.Token()), "the API token should be redacted")
+	assert.True(t, strings.Contains(string(data), typeformtest.RedactedValue))
+
+	// replay without the server
+	replayer, err := typeformtest.LoadReplayer(cassettePath)
+	assert.Nil(t, err, "no error should occur")
+	replayClient, err := typeform.NewClient(typeform.Latest,
+		typeform.WithBaseURL("http://127.0.0.1:1"),
+		typeform.WithTransport(replayer),
+		typeform.WithRetryPolicy(typeform.RetryPolicy{MaxAttempts: 1}),
+	)
+	assert.Nil(t, err, "no error should occur")
+	replayClient.SetAPIToken("any")
+
+	replayed, err := replayClient.CreateForm(form)
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, recorded, replayed)
+	_, err = replayClient.GetDesign("missing")
+	assert.True(t, typeform.IsNotFound(err), "recorded errors should be replayed")
+	assert.Len(t, replayer.Unused(), 0)
+
+	// strict matching
+	otherForm := form
+	otherForm.Title = "Changed"
+	_, err = replayClient.CreateForm(otherForm)
+	assert.NotNil(t, err, "a request with a different body should not match")
+	_, err = replayClient.GetDesign("missing")
+	assert.NotNil(t, err, "an interaction should be replayed once")
+}
@@ -0,0 +1,111 @@
+package typeformtest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault makes the server misbehave on the requests it matches
+type Fault struct {
+	Method string // The method of the requests to match; "" matches any method
+	Path   string // The prefix of the path of the resources to match, without the API version, e.g. "/forms"; "" matches any path
+
+	Latency    time.Duration // How long to wait before handling the request
+	StatusCode int           // If not 0, the request fails with this status, e.g. 500 or 429
+	RetryAfter time.Duration // The Retry-After header sent with a failure, rounded up to seconds; 0 sends none
+
+	Times int // The number of requests to affect; 0 affects all the matching requests
+}
+
+// Latency returns a Fault delaying all the requests by latency
+func Latency(latency time.Duration) Fault {
+	return Fault{Latency: latency}
+}
+
+// ServerErrors returns a Fault failing the next times requests with a 500 status
+func ServerErrors(times int) Fault {
+	return Fault{StatusCode: http.StatusInternalServerError, Times: times}
+}
+
+// RateLimited returns a Fault failing the next times requests with a 429 status
+func RateLimited(times int, retryAfter time.Duration) Fault {
+	return Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: retryAfter, Times: times}
+}
+
+// InjectFault adds a fault to the server; faults are applied in the order they are added
+func (server *Server) InjectFault(fault Fault) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.faults = append(server.faults, &fault)
+}
+
+// ClearFaults removes all the faults of the server
+func (server *Server) ClearFaults() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.faults = nil
+}
+
+func (fault *Fault) matches(r *http.Request, resourcePath string) bool {
+	if fault.Method != "" && fault.Method != r.Method {
+		return false
+	}
+	return strings.HasPrefix(resourcePath, fault.Path)
+}
+
+// matchingFaults returns the faults matching a request, consuming one of their times
+func (server *Server) matchingFaults(r *http.Request, resourcePath string) []Fault {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	matching := []Fault{}
+	remaining := server.faults[:0]
+	for _, fault := range server.faults {
+		if fault.matches(r, resourcePath) {
+			matching = append(matching, *fault)
+			if fault.Times > 0 {
+				fault.Times--
+				if fault.Times == 0 {
+					continue
+				}
+			}
+		}
+		remaining = append(remaining, fault)
+	}
+	server.faults = remaining
+	return matching
+}
+
+// applyFaults applies the faults matching a request; it returns true if the request failed
+func (server *Server) applyFaults(w http.ResponseWriter, r *http.Request, resourcePath string) bool {
+	for _, fault := range server.matchingFaults(r, resourcePath) {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return true
+			}
+		}
+		if fault.StatusCode == 0 {
+			continue
+		}
+
+		if fault.RetryAfter > 0 {
+			seconds := (fault.RetryAfter + time.Second - 1) / time.Second
+			w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+		}
+		switch {
+		case fault.StatusCode == http.StatusTooManyRequests:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			writeError(w, fault.StatusCode, "rate_limited", "", "too many requests")
+		case fault.StatusCode >= 500:
+			writeError(w, fault.StatusCode, "internal_server_error", "", "injected server error")
+		default:
+			writeError(w, fault.StatusCode, "injected_fault", "", "injected fault")
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,666 @@
+// Package typeformtest provides an in-process fake of the Typeform I/O API, to test
+// the code using the typeform package without network access nor an API key.
+//
+//	server := typeformtest.NewServer()
+//	defer server.Close()
+//
+//	client, err := server.NewClient()
+//	...
+//	formInfo, err := client.CreateForm(form)
+package typeformtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+)
+
+// DefaultAPIToken is the API token accepted by a Server, unless changed with WithAPIToken
+const DefaultAPIToken = "typeformtest-token"
+
+// APIVersion is the version reported by the resources of a Server
+const APIVersion = "v0.4"
+
+// Server is a fake Typeform I/O API, keeping its state in memory
+type Server struct {
+	URL string // The base URL of the server, to be used with typeform.WithBaseURL
+
+	server   *httptest.Server
+	mu       sync.Mutex
+	token    string
+	rand     *rand.Rand
+	now      func() time.Time
+	faults   []*Fault
+	requests int
+
+	forms     map[string]*storedForm
+	images    map[string]typeform.ImageInfo
+	designs   map[string]typeform.DesignInfo
+	urls      map[string]typeform.URLInfo
+	responses map[string][]typeform.Response
+}
+
+type storedForm struct {
+	form typeform.Form
+	info typeform.FormInfo
+}
+
+// Option configures a Server in NewServer
+type Option func(server *Server)
+
+// WithAPIToken sets the API token accepted by the server
+func WithAPIToken(token string) Option {
+	return func(server *Server) {
+		server.token = token
+	}
+}
+
+// WithSeed sets the seed of the generator of the IDs of the server; servers with the same seed generate the same IDs
+func WithSeed(seed int64) Option {
+	return func(server *Server) {
+		server.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithClock sets the function returning the current time of the server
+func WithClock(now func() time.Time) Option {
+	return func(server *Server) {
+		server.now = now
+	}
+}
+
+// NewServer starts a new Server; it must be closed when no longer needed
+func NewServer(options ...Option) *Server {
+	server := &Server{
+		token:     DefaultAPIToken,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:       time.Now,
+		forms:     map[string]*storedForm{},
+		images:    map[string]typeform.ImageInfo{},
+		designs:   map[string]typeform.DesignInfo{},
+		urls:      map[string]typeform.URLInfo{},
+		responses: map[string][]typeform.Response{},
+	}
+	for _, option := range options {
+		option(server)
+	}
+
+	server.server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	server.URL = server.server.URL
+	return server
+}
+
+// Close shuts the server down
+func (server *Server) Close() {
+	server.server.Close()
+}
+
+// Token returns the API token accepted by the server
+func (server *Server) Token() string {
+	return server.token
+}
+
+// NewClient returns a client sending its requests to the server, authenticated with its token
+func (server *Server) NewClient(options ...typeform.ClientOption) (*typeform.Client, error) {
+	options = append([]typeform.ClientOption{typeform.WithBaseURL(server.URL)}, options...)
+	client, err := typeform.NewClient(typeform.Latest, options...)
+	if err != nil {
+		return nil, err
+	}
+	err = client.SetAPIToken(server.token)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// Requests returns the number of requests received by the server, including the ones that failed
+func (server *Server) Requests() int {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.requests
+}
+
+// Form returns the form with the given ID, as sent when it was created
+func (server *Server) Form(formID string) (typeform.Form, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	stored, ok := server.forms[formID]
+	if !ok {
+		return typeform.Form{}, false
+	}
+	return stored.form, true
+}
+
+// Image returns the image with the given ID
+func (server *Server) Image(imageID string) (typeform.ImageInfo, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	image, ok := server.images[imageID]
+	return image, ok
+}
+
+// Design returns the design with the given ID
+func (server *Server) Design(designID string) (typeform.DesignInfo, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	design, ok := server.designs[designID]
+	return design, ok
+}
+
+// FormURL returns the URL with the given ID
+func (server *Server) FormURL(URLID string) (typeform.URLInfo, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	URLInfo, ok := server.urls[URLID]
+	return URLInfo, ok
+}
+
+// AddResponses adds responses to a form, to be returned by GetResponses;
+// the responses without a token are given a random one
+func (server *Server) AddResponses(formID string, responses ...typeform.Response) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	for _, response := range responses {
+		if response.Token == "" {
+			response.Token = server.newToken()
+		}
+		if response.ResponseID == "" {
+			response.ResponseID = response.Token
+		}
+		server.responses[formID] = append(server.responses[formID], response)
+	}
+}
+
+const idAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// newID returns a random ID shaped like the ones of the API, e.g. "HNdAk47LS"
+func (server *Server) newID() string {
+	id := make([]byte, 9)
+	for i := range id {
+		id[i] = idAlphabet[server.rand.Intn(len(idAlphabet))]
+	}
+	return string(id)
+}
+
+// newToken returns a random response token shaped like the ones of the API
+func (server *Server) newToken() string {
+	return fmt.Sprintf("%016x%016x", server.rand.Uint64(), server.rand.Uint64())
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(payload)
+}
+
+// writeError writes an error response in the shape of typeform.APIError
+func writeError(w http.ResponseWriter, statusCode int, errorName, field, description string) {
+	writeJSON(w, statusCode, typeform.APIError{
+		Error:       errorName,
+		Field:       field,
+		Description: description,
+	})
+}
+
+func notFound(w http.ResponseWriter, resource string) {
+	writeError(w, http.StatusNotFound, "not_found", "", fmt.Sprintf("%s not found", resource))
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "", "method not allowed")
+}
+
+// routePattern splits a request path into the API version and the path of the resource
+var routePattern = regexp.MustCompile(`^/([^/]+)(/.*)?$`)
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mu.Lock()
+	server.requests++
+	server.mu.Unlock()
+
+	match := routePattern.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		notFound(w, "resource")
+		return
+	}
+	resourcePath := match[2]
+	if resourcePath == "" {
+		resourcePath = "/"
+	}
+
+	if server.applyFaults(w, r, resourcePath) {
+		return
+	}
+
+	if r.Header.Get("X-API-TOKEN") != server.token {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "", "invalid API token")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(resourcePath, "/"), "/")
+	switch {
+	case resourcePath == "/":
+		server.baseInfo(w, r)
+	case segments[0] == "forms" && len(segments) == 1:
+		server.createForm(w, r)
+	case segments[0] == "forms" && len(segments) == 2:
+		server.getForm(w, r, segments[1])
+	case segments[0] == "forms" && len(segments) == 3 && segments[2] == "responses":
+		server.getResponses(w, r, segments[1])
+	case segments[0] == "images" && len(segments) == 1:
+		server.createImage(w, r)
+	case segments[0] == "images" && len(segments) == 2:
+		server.getImage(w, r, segments[1])
+	case segments[0] == "designs" && len(segments) == 1:
+		server.createDesign(w, r)
+	case segments[0] == "designs" && len(segments) == 2:
+		server.getDesign(w, r, segments[1])
+	case segments[0] == "urls" && len(segments) == 1:
+		server.createURL(w, r)
+	case segments[0] == "urls" && len(segments) == 2:
+		server.handleURL(w, r, segments[1])
+	default:
+		notFound(w, "resource")
+	}
+}
+
+// decodeBody decodes the JSON body of a request, writing a 400 response if it is invalid
+func decodeBody(w http.ResponseWriter, r *http.Request, payload interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(payload)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "", fmt.Sprintf("invalid JSON body: %s", err))
+		return false
+	}
+	return true
+}
+
+func (server *Server) link(rel string, parts ...string) typeform.Link {
+	return typeform.Link{
+		REL:  rel,
+		HREF: server.URL + "/" + APIVersion + "/" + strings.Join(parts, "/"),
+	}
+}
+
+func (server *Server) baseInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"name":          "Typeform I/O",
+		"description":   "Build beautiful, interactive and intelligent forms (fake)",
+		"version":       APIVersion,
+		"documentation": "https://docs.typeform.io/",
+		"support":       "support@typeform.io",
+		"time":          server.now().UTC().Format(typeform.TimestampFormat),
+	})
+}
+
+func (server *Server) createForm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+	var form typeform.Form
+	if !decodeBody(w, r, &form) {
+		return
+	}
+
+	// only the rules of the API are enforced: Validate doesn't lint the logic jumps, as AnalyzeLogic does
+	err := form.Validate()
+	if validationErrors, ok := err.(typeform.ValidationErrors); ok && len(validationErrors) > 0 {
+		violation := validationErrors[0]
+		writeError(w, http.StatusBadRequest, "validation_error", violation.Path, violation.Message)
+		return
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if form.DesignID != "" {
+		if _, ok := server.designs[form.DesignID]; !ok {
+			writeError(w, http.StatusBadRequest, "validation_error", "design_id", fmt.Sprintf("design %s does not exist", form.DesignID))
+			return
+		}
+	}
+	for i, URLID := range form.URLIDs {
+		if _, ok := server.urls[URLID]; !ok {
+			writeError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("url_ids[%d]", i), fmt.Sprintf("url %s does not exist", URLID))
+			return
+		}
+	}
+
+	formID := server.newID()
+	URLIDs := form.URLIDs
+	if len(URLIDs) == 0 {
+		URLIDs = []string{server.newID()}
+	}
+	formInfo := typeform.FormInfo{
+		ID:      formID,
+		Title:   form.Title,
+		Fields:  form.Fields,
+		Version: APIVersion,
+		Links: []typeform.Link{
+			server.link("self", "forms", formID),
+			{REL: "form_render", HREF: "https://forms.typeform.io/to/" + URLIDs[0]},
+		},
+	}
+	for _, URLID := range URLIDs {
+		server.urls[URLID] = server.newURLInfo(URLID, formID)
+	}
+	server.forms[formID] = &storedForm{form: form, info: formInfo}
+
+	writeJSON(w, http.StatusCreated, server.formInfo(formID))
+}
+
+// formInfo returns the info about a form, with the URLs currently linking to it
+func (server *Server) formInfo(formID string) typeform.FormInfo {
+	formInfo := server.forms[formID].info
+	formInfo.URLs = []typeform.URL{}
+	for _, URLInfo := range server.urls {
+		if URLInfo.FormID == formID {
+			formInfo.URLs = append(formInfo.URLs, typeform.URL{ID: URLInfo.ID, FormID: formID, Version: APIVersion})
+		}
+	}
+	sort.Slice(formInfo.URLs, func(i, j int) bool {
+		return formInfo.URLs[i].ID < formInfo.URLs[j].ID
+	})
+	return formInfo
+}
+
+func (server *Server) getForm(w http.ResponseWriter, r *http.Request, formID string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if _, ok := server.forms[formID]; !ok {
+		notFound(w, "form")
+		return
+	}
+	writeJSON(w, http.StatusOK, server.formInfo(formID))
+}
+
+func (server *Server) getResponses(w http.ResponseWriter, r *http.Request, formID string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if _, ok := server.forms[formID]; !ok {
+		notFound(w, "form")
+		return
+	}
+
+	query := r.URL.Query()
+	intParameter := func(name string, defaultValue int) (int, bool) {
+		value := query.Get(name)
+		if value == "" {
+			return defaultValue, true
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeError(w, http.StatusBadRequest, "validation_error", name, fmt.Sprintf("%s must be a positive integer", name))
+			return 0, false
+		}
+		return parsed, true
+	}
+	offset, ok := intParameter("offset", 0)
+	if !ok {
+		return
+	}
+	limit, ok := intParameter("limit", 25)
+	if !ok {
+		return
+	}
+	if limit > typeform.MaxResponsesPageSize {
+		limit = typeform.MaxResponsesPageSize
+	}
+	since, ok := intParameter("since", 0)
+	if !ok {
+		return
+	}
+	until, ok := intParameter("until", 0)
+	if !ok {
+		return
+	}
+
+	matching := []typeform.Response{}
+	for _, response := range server.responses[formID] {
+		if completed := query.Get("completed"); completed != "" && strconv.FormatBool(response.Completed()) != completed {
+			continue
+		}
+		if since > 0 && response.SubmittedAt.Unix() < int64(since) {
+			continue
+		}
+		if until > 0 && response.SubmittedAt.Unix() > int64(until) {
+			continue
+		}
+		matching = append(matching, response)
+	}
+	if strings.HasSuffix(query.Get("order_by"), ",desc") {
+		sort.SliceStable(matching, func(i, j int) bool {
+			return matching[i].SubmittedAt.After(matching[j].SubmittedAt)
+		})
+	}
+
+	page := typeform.Responses{
+		TotalItems: len(matching),
+		Items:      []typeform.Response{},
+	}
+	if limit > 0 {
+		page.PageCount = (len(matching) + limit - 1) / limit
+	}
+	for i := offset; i < len(matching) && i < offset+limit; i++ {
+		page.Items = append(page.Items, matching[i])
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (server *Server) createImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+	var newImage struct {
+		URL string `json:"url"`
+	}
+	if !decodeBody(w, r, &newImage) {
+		return
+	}
+	imageURL, err := url.Parse(newImage.URL)
+	if err != nil || imageURL.Scheme == "" || imageURL.Host == "" {
+		writeError(w, http.StatusBadRequest, "validation_error", "url", "url must be an absolute URL")
+		return
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	imageID := server.newID()
+	imageType := imageTypes[strings.ToLower(path.Ext(imageURL.Path))]
+	if imageType == "" {
+		imageType = "image/png"
+	}
+	server.images[imageID] = typeform.ImageInfo{
+		ID:       imageID,
+		Filename: path.Base(imageURL.Path),
+		Type:     imageType,
+		URL:      "https://images.typeform.io/" + imageID + path.Ext(imageURL.Path),
+		Width:    800,
+		Height:   600,
+		Version:  APIVersion,
+	}
+
+	writeJSON(w, http.StatusCreated, typeform.NewImage{
+		ID:          imageID,
+		OriginalURL: newImage.URL,
+		Type:        imageType,
+		Version:     APIVersion,
+	})
+}
+
+var imageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+}
+
+func (server *Server) getImage(w http.ResponseWriter, r *http.Request, imageID string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	image, ok := server.Image(imageID)
+	if !ok {
+		notFound(w, "image")
+		return
+	}
+	writeJSON(w, http.StatusOK, image)
+}
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func (server *Server) createDesign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+	var design typeform.Design
+	if !decodeBody(w, r, &design) {
+		return
+	}
+	colors := []struct{ name, value string }{
+		{"question", design.Colors.Question},
+		{"button", design.Colors.Button},
+		{"answer", design.Colors.Answer},
+		{"background", design.Colors.Background},
+	}
+	for _, color := range colors {
+		if color.value != "" && !colorPattern.MatchString(color.value) {
+			writeError(w, http.StatusBadRequest, "validation_error", "colors."+color.name, "color must be in the #RRGGBB format")
+			return
+		}
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	designInfo := typeform.DesignInfo{
+		ID:      server.newID(),
+		Font:    design.Font,
+		Version: APIVersion,
+	}
+	designInfo.Colors.Question = design.Colors.Question
+	designInfo.Colors.Button = design.Colors.Button
+	designInfo.Colors.Answer = design.Colors.Answer
+	designInfo.Colors.Background = design.Colors.Background
+	server.designs[designInfo.ID] = designInfo
+
+	writeJSON(w, http.StatusCreated, designInfo)
+}
+
+func (server *Server) getDesign(w http.ResponseWriter, r *http.Request, designID string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	design, ok := server.Design(designID)
+	if !ok {
+		notFound(w, "design")
+		return
+	}
+	writeJSON(w, http.StatusOK, design)
+}
+
+func (server *Server) newURLInfo(URLID, formID string) typeform.URLInfo {
+	return typeform.URLInfo{
+		ID:      URLID,
+		FormID:  formID,
+		Version: APIVersion,
+		Links: []typeform.Link{
+			server.link("self", "urls", URLID),
+			{REL: "form_render", HREF: "https://forms.typeform.io/to/" + URLID},
+		},
+	}
+}
+
+// decodeURLBody decodes the body of a request to create or modify a URL, and checks that its form exists
+func (server *Server) decodeURLBody(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var body struct {
+		FormID string `json:"form_id"`
+	}
+	if !decodeBody(w, r, &body) {
+		return "", false
+	}
+	if _, ok := server.forms[body.FormID]; !ok {
+		writeError(w, http.StatusBadRequest, "validation_error", "form_id", fmt.Sprintf("form %s does not exist", body.FormID))
+		return "", false
+	}
+	return body.FormID, true
+}
+
+func (server *Server) createURL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	formID, ok := server.decodeURLBody(w, r)
+	if !ok {
+		return
+	}
+	URLInfo := server.newURLInfo(server.newID(), formID)
+	server.urls[URLInfo.ID] = URLInfo
+	writeJSON(w, http.StatusCreated, URLInfo)
+}
+
+func (server *Server) handleURL(w http.ResponseWriter, r *http.Request, URLID string) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	URLInfo, ok := server.urls[URLID]
+	if !ok {
+		notFound(w, "url")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, URLInfo)
+	case http.MethodPut:
+		formID, ok := server.decodeURLBody(w, r)
+		if !ok {
+			return
+		}
+		URLInfo.FormID = formID
+		server.urls[URLID] = URLInfo
+		writeJSON(w, http.StatusOK, URLInfo)
+	case http.MethodDelete:
+		delete(server.urls, URLID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
@@ -0,0 +1,112 @@
+package typeformtest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	typeform "github.com/gagliardetto/go-ask-awesomely"
+	"github.com/gagliardetto/go-ask-awesomely/typeformtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerResources(t *testing.T) {
+	server := typeformtest.NewServer(typeformtest.WithSeed(1))
+	defer server.Close()
+
+	client, err := server.NewClient()
+	assert.Nil(t, err, "no error should occur")
+
+	baseInfo, err := client.BaseInfo()
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, typeformtest.APIVersion, baseInfo.Version)
+
+	design, err := client.CreateDesign(typeform.Design{Colors: typeform.Colors{Question: "#3D3D3D"}, Font: "Arial"})
+	assert.Nil(t, err, "no error should occur")
+
+	form, err := typeform.NewForm("Survey").DesignID(design.ID).ShortText("Name?").Build()
+	assert.Nil(t, err, "no error should occur")
+	formInfo, err := client.CreateForm(form)
+	assert.Nil(t, err, "no error should occur")
+	assert.Len(t, formInfo.ID, 9)
+	assert.Len(t, formInfo.URLs, 1, "a URL should be created for the form")
+	assert.Equal(t, "self", formInfo.Links[0].REL)
+	assert.Equal(t, server.URL+"/v0.4/forms/"+formInfo.ID, formInfo.Links[0].HREF)
+
+	fetched, err := client.GetForm(formInfo.ID)
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, "Survey", fetched.Title)
+
+	otherForm, err := client.CreateForm(form)
+	assert.Nil(t, err, "no error should occur")
+	URLInfo, err := client.ModifyURL(formInfo.URLs[0].ID, otherForm.ID)
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, otherForm.ID, URLInfo.FormID)
+	fetched, _ = client.GetForm(otherForm.ID)
+	assert.Len(t, fetched.URLs, 2, "the modified URL should link to the other form")
+
+	assert.Nil(t, client.DeleteURL(URLInfo.ID))
+	_, err = client.GetURL(URLInfo.ID)
+	assert.True(t, typeform.IsNotFound(err), "a deleted URL should not be found")
+
+	image, err := client.CreateImage("https://example.com/logo.jpg")
+	assert.Nil(t, err, "no error should occur")
+	imageInfo, err := client.GetImage(image.ID)
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, "logo.jpg", imageInfo.Filename)
+	assert.Equal(t, "image/jpeg", imageInfo.Type)
+
+	server.AddResponses(formInfo.ID, typeform.Response{SubmittedAt: time.Unix(1500000000, 0)}, typeform.Response{})
+	responses, err := client.GetResponses(formInfo.ID, typeform.ResponsesQuery{Limit: 1})
+	assert.Nil(t, err, "no error should occur")
+	assert.Equal(t, 2, responses.TotalItems)
+	assert.Len(t, responses.Items, 1)
+	assert.Len(t, responses.Items[0].Token, 32)
+}
+
+func TestServerErrors(t *testing.T) {
+	server := typeformtest.NewServer()
+	defer server.Close()
+
+	client, err := server.NewClient()
+	assert.Nil(t, err, "no error should occur")
+
+	_, err = client.CreateForm(typeform.Form{Title: "Broken", Fields: []typeform.Field{{Type: typeform.ShortText}}})
+	var validationError *typeform.FormValidationError
+	assert.True(t, errors.As(err, &validationError), "the validation error should be located in the form")
+	assert.Equal(t, "fields[0].question", validationError.APIError.Field)
+	assert.Equal(t, 0, validationError.FieldIndex)
+
+	_, err = client.GetDesign("missing")
+	assert.True(t, typeform.IsNotFound(err))
+
+	client.SetAPIToken("wrong")
+	_, err = client.BaseInfo()
+	assert.True(t, typeform.IsUnauthorized(err))
+}
+
+func TestServerFaults(t *testing.T) {
+	server := typeformtest.NewServer()
+	defer server.Close()
+
+	client, err := server.NewClient(typeform.WithRetryPolicy(typeform.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+	assert.Nil(t, err, "no error should occur")
+
+	server.InjectFault(typeformtest.ServerErrors(2))
+	_, err = client.BaseInfo()
+	assert.Nil(t, err, "the request should succeed after the injected errors")
+	assert.Equal(t, 3, server.Requests())
+
+	server.InjectFault(typeformtest.Fault{Method: http.MethodGet, Path: "/designs", StatusCode: http.StatusTooManyRequests})
+	_, err = client.GetDesign("any")
+	assert.True(t, typeform.IsRateLimited(err))
+	_, err = client.BaseInfo()
+	assert.Nil(t, err, "faults should only affect the matching requests")
+
+	server.ClearFaults()
+	server.InjectFault(typeformtest.Latency(20 * time.Millisecond))
+	start := time.Now()
+	client.BaseInfo()
+	assert.True(t, time.Since(start) >= 20*time.Millisecond, "the latency should be applied")
+}
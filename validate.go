@@ -0,0 +1,135 @@
+package typeform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violation is a rule broken by a part of a Form
+type Violation struct {
+	Path    string // The path of the offending part, in the same format accepted by LocatePath, e.g. "fields[2].choices"
+	Message string
+}
+
+func (violation Violation) String() string {
+	if violation.Path == "" {
+		return violation.Message
+	}
+	return fmt.Sprintf("%s: %s", violation.Path, violation.Message)
+}
+
+// ValidationErrors is the error returned by Form.Validate, ValidateAnswer and ValidateSubmission;
+// it contains all the violations found
+type ValidationErrors []Violation
+
+func (validationErrors ValidationErrors) Error() string {
+	messages := make([]string, len(validationErrors))
+	for i, violation := range validationErrors {
+		messages[i] = violation.String()
+	}
+	return fmt.Sprintf("%d violation(s): %s", len(validationErrors), strings.Join(messages, "; "))
+}
+
+func (validationErrors *ValidationErrors) add(path string, format string, args ...interface{}) {
+	*validationErrors = append(*validationErrors, Violation{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// fieldTypes are the field types known to the API
+var fieldTypes = map[FieldType]bool{
+	ShortText:      true,
+	LongText:       true,
+	MultipleChoice: true,
+	PictureChoice:  true,
+	Statement:      true,
+	Dropdown:       true,
+	YesNo:          true,
+	Number:         true,
+	Rating:         true,
+	OpinionScale:   true,
+	Email:          true,
+	Website:        true,
+	Legal:          true,
+}
+
+// Validate checks the form against the rules enforced by the API, without making any request;
+// logic jumps the API accepts but that are likely mistakes are reported by AnalyzeLogic instead;
+// it returns nil or ValidationErrors containing all the violations found
+func (form Form) Validate() error {
+	var validationErrors ValidationErrors
+
+	if strings.TrimSpace(form.Title) == "" {
+		validationErrors.add("title", "is required")
+	}
+	if len(form.Fields) == 0 {
+		validationErrors.add("fields", "at least one field is required")
+	}
+
+	refs := map[string]int{}
+	for i, field := range form.Fields {
+		path := fmt.Sprintf("fields[%d]", i)
+		validateField(&validationErrors, path, field)
+
+		if field.Ref == "" {
+			continue
+		}
+		if first, ok := refs[field.Ref]; ok {
+			validationErrors.add(path+".ref", "%q is already used by fields[%d]", field.Ref, first)
+			continue
+		}
+		refs[field.Ref] = i
+	}
+
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return validationErrors
+}
+
+func validateField(validationErrors *ValidationErrors, path string, field Field) {
+	if !fieldTypes[field.Type] {
+		validationErrors.add(path+".type", "unknown field type %q", field.Type)
+		return
+	}
+	if strings.TrimSpace(field.Question) == "" {
+		validationErrors.add(path+".question", "is required")
+	}
+
+	switch field.Type {
+	case ShortText, LongText:
+		if field.MaxCharacters < 0 {
+			validationErrors.add(path+".max_characters", "must not be negative, got %d", field.MaxCharacters)
+		}
+	case MultipleChoice, PictureChoice, Dropdown:
+		if len(field.Choices) == 0 {
+			validationErrors.add(path+".choices", "at least one choice is required for %s fields", field.Type)
+		}
+		for j, choice := range field.Choices {
+			choicePath := fmt.Sprintf("%s.choices[%d]", path, j)
+			if field.Type == PictureChoice {
+				if choice.ImageID == "" {
+					validationErrors.add(choicePath+".image_id", "is required for picture choices")
+				}
+			} else if strings.TrimSpace(choice.Label) == "" {
+				validationErrors.add(choicePath+".label", "is required")
+			}
+		}
+	case Number:
+		// a zero MaxValue is not sent to the API, so it means no maximum unless set explicitly
+		if minValue, hasMin, maxValue, hasMax := field.Bounds(); hasMin && hasMax && minValue > maxValue {
+			validationErrors.add(path+".min_value", "must not be greater than max_value (%d > %d)", field.MinValue, field.MaxValue)
+		}
+	case Rating:
+		// a zero Steps is not sent to the API, which then uses its default
+		if field.Steps != 0 && (field.Steps < 1 || field.Steps > 10) {
+			validationErrors.add(path+".steps", "must be between 1 and 10, got %d", field.Steps)
+		}
+	case OpinionScale:
+		// a zero Steps is not sent to the API, which then uses its default
+		if field.Steps != 0 && (field.Steps < 5 || field.Steps > 11) {
+			validationErrors.add(path+".steps", "must be between 5 and 11, got %d", field.Steps)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package typeform
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"unicode/utf8"
+)
+
+// ValidateAnswer checks the answer against the constraints of the field: the type of the answer,
+// the format of emails and URLs, the maximum characters of texts, the bounds of numbers, the steps of ratings
+// and opinion scales, and the labels of the selected choices; it returns nil or ValidationErrors
+// containing all the violations found, with paths relative to the answer, e.g. "choices.labels[1]"
+func ValidateAnswer(field Field, answer Answer) error {
+	var validationErrors ValidationErrors
+	validateAnswer(&validationErrors, "", field, answer)
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return validationErrors
+}
+
+// ValidateSubmission checks the answers submitted to the form, e.g. imported from offline channels:
+// each answer is checked with ValidateAnswer, every required field seen by the respondent must be answered,
+// and no field skipped by the logic jumps can be answered. Answers are matched to the fields of the form
+// by the Ref of their field, or by their field ID, which must then be a Ref or a path such as "fields[2]"
+// (as in the responses of RunForm). It returns nil or ValidationErrors containing all the violations found,
+// with paths such as "answers[3].number" or "fields[1]".
+func ValidateSubmission(form Form, answers []Answer) error {
+	var validationErrors ValidationErrors
+
+	refs := fieldRefs(form)
+	answerIndexes := map[int]int{} // index of the answer by index of its field
+	answersByRef := map[string]Answer{}
+	for i, answer := range answers {
+		path := fmt.Sprintf("answers[%d]", i)
+		index, ok := answerFieldIndex(form, refs, answer.Field)
+		if !ok {
+			validationErrors.add(path+".field", "no field of the form has ref %q or id %q", answer.Field.Ref, answer.Field.ID)
+			continue
+		}
+		if first, ok := answerIndexes[index]; ok {
+			validationErrors.add(path+".field", "fields[%d] is already answered by answers[%d]", index, first)
+			continue
+		}
+		answerIndexes[index] = i
+		if ref := form.Fields[index].Ref; ref != "" {
+			answersByRef[ref] = answer
+		}
+		validateAnswer(&validationErrors, path, form.Fields[index], answer)
+	}
+
+	// the fields seen by the respondent, following the logic jumps with the answers
+	seen := make([]bool, len(form.Fields))
+	for i := 0; i < len(form.Fields) && !seen[i]; i = form.NextField(i, answersByRef) {
+		seen[i] = true
+	}
+	for i, field := range form.Fields {
+		answerIndex, answered := answerIndexes[i]
+		switch {
+		case seen[i] && field.Required && !answered:
+			validationErrors.add(fmt.Sprintf("fields[%d]", i), "is required, but has no answer")
+		case !seen[i] && answered:
+			validationErrors.add(fmt.Sprintf("answers[%d].field", answerIndex), "fields[%d] is skipped by the logic jumps, so it can't be answered", i)
+		}
+	}
+
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return validationErrors
+}
+
+// answerFieldIndex returns the index of the field of the form that an answer is for
+func answerFieldIndex(form Form, refs map[string]int, answerField AnswerField) (int, bool) {
+	if answerField.Ref != "" {
+		index, ok := refs[answerField.Ref]
+		return index, ok
+	}
+	if index, ok := refs[answerField.ID]; ok {
+		return index, true
+	}
+	for i := range form.Fields {
+		if answerField.ID == fmt.Sprintf("fields[%d]", i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func validateAnswer(validationErrors *ValidationErrors, path string, field Field, answer Answer) {
+	join := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+	// expect checks the type of the answer, and that it has a value
+	expect := func(answerType AnswerType, hasValue bool, valueName string) bool {
+		if answer.Type != answerType {
+			validationErrors.add(join("type"), "must be %q for %s fields, got %q", answerType, field.Type, answer.Type)
+			return false
+		}
+		if !hasValue {
+			validationErrors.add(join(valueName), "is missing")
+			return false
+		}
+		return true
+	}
+
+	if answer.Field.Type != "" && answer.Field.Type != field.Type {
+		validationErrors.add(join("field.type"), "is %s, but the field is a %s field", answer.Field.Type, field.Type)
+		return
+	}
+
+	switch field.Type {
+	case ShortText, LongText:
+		if !expect(TextAnswer, answer.Text != "", "text") {
+			return
+		}
+		if field.MaxCharacters > 0 && utf8.RuneCountInString(answer.Text) > field.MaxCharacters {
+			validationErrors.add(join("text"), "must be at most %d characters long", field.MaxCharacters)
+		}
+
+	case Email:
+		if !expect(EmailAnswer, answer.Email != "", "email") {
+			return
+		}
+		address, err := mail.ParseAddress(answer.Email)
+		if err != nil || address.Address != answer.Email {
+			validationErrors.add(join("email"), "not a valid email address")
+		}
+
+	case Website:
+		if !expect(URLAnswer, answer.URL != "", "url") {
+			return
+		}
+		parsed, err := url.Parse(answer.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			validationErrors.add(join("url"), "not a valid URL, e.g. https://example.com")
+		}
+
+	case Number:
+		if !expect(NumberAnswer, answer.Number != nil, "number") {
+			return
+		}
+		minValue, hasMin, maxValue, hasMax := field.Bounds()
+		if hasMin && *answer.Number < minValue {
+			validationErrors.add(join("number"), "must be at least %d", minValue)
+		}
+		if hasMax && *answer.Number > maxValue {
+			validationErrors.add(join("number"), "must be at most %d", maxValue)
+		}
+
+	case Rating, OpinionScale:
+		if !expect(NumberAnswer, answer.Number != nil, "number") {
+			return
+		}
+		first, last := stepRange(field)
+		if *answer.Number < first || *answer.Number > last {
+			validationErrors.add(join("number"), "must be between %d and %d", first, last)
+		}
+
+	case YesNo, Legal:
+		expect(BooleanAnswer, answer.Boolean != nil, "boolean")
+
+	case MultipleChoice, PictureChoice, Dropdown:
+		if field.AllowMultipleSelections {
+			if !expect(ChoicesAnswer, answer.Choices != nil, "choices") {
+				return
+			}
+			if len(answer.Choices.Labels) == 0 && answer.Choices.Other == "" {
+				validationErrors.add(join("choices"), "no choice is selected")
+			}
+			for i, label := range answer.Choices.Labels {
+				validateChoiceLabel(validationErrors, join(fmt.Sprintf("choices.labels[%d]", i)), field, label)
+			}
+			validateOtherChoice(validationErrors, join("choices.other"), field, answer.Choices.Other)
+			return
+		}
+		if !expect(ChoiceAnswer, answer.Choice != nil, "choice") {
+			return
+		}
+		switch {
+		case answer.Choice.Label != "" && answer.Choice.Other != "":
+			validationErrors.add(join("choice"), "only one choice can be selected")
+		case answer.Choice.Other != "":
+			validateOtherChoice(validationErrors, join("choice.other"), field, answer.Choice.Other)
+		default:
+			validateChoiceLabel(validationErrors, join("choice.label"), field, answer.Choice.Label)
+		}
+
+	case Statement:
+		validationErrors.add(join("field"), "statements can't be answered")
+	}
+}
+
+func validateChoiceLabel(validationErrors *ValidationErrors, path string, field Field, label string) {
+	for _, choice := range field.Choices {
+		if choice.Label == label {
+			return
+		}
+	}
+	validationErrors.add(path, "%q is not a choice of the field", label)
+}
+
+func validateOtherChoice(validationErrors *ValidationErrors, path string, field Field, other string) {
+	if other != "" && !field.AddOtherChoice {
+		validationErrors.add(path, "the field has no \"Other\" choice")
+	}
+}
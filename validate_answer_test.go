@@ -0,0 +1,111 @@
+package typeform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func violationPaths(err error) map[string]string {
+	paths := map[string]string{}
+	if validationErrors, ok := err.(ValidationErrors); ok {
+		for _, violation := range validationErrors {
+			paths[violation.Path] = violation.Message
+		}
+	}
+	return paths
+}
+
+func TestValidateAnswer(t *testing.T) {
+	number := func(value int) *int { return &value }
+
+	tests := []struct {
+		name   string
+		field  Field
+		answer Answer
+		path   string // The path of the expected violation; empty if the answer is valid
+	}{
+		{"text", Field{Type: ShortText, MaxCharacters: 5}, Answer{Type: TextAnswer, Text: "John"}, ""},
+		{"text too long", Field{Type: ShortText, MaxCharacters: 5}, Answer{Type: TextAnswer, Text: "Johnny"}, "text"},
+		{"wrong type", Field{Type: ShortText}, Answer{Type: NumberAnswer, Number: number(1)}, "type"},
+		{"wrong field type", Field{Type: ShortText}, Answer{Field: AnswerField{Type: Email}, Type: TextAnswer, Text: "a"}, "field.type"},
+		{"email", Field{Type: Email}, Answer{Type: EmailAnswer, Email: "john@example.com"}, ""},
+		{"invalid email", Field{Type: Email}, Answer{Type: EmailAnswer, Email: "John <john@example.com>"}, "email"},
+		{"url", Field{Type: Website}, Answer{Type: URLAnswer, URL: "https://example.com/page"}, ""},
+		{"invalid url", Field{Type: Website}, Answer{Type: URLAnswer, URL: "example.com"}, "url"},
+		{"number", Field{Type: Number, MinValue: 1, MaxValue: 10}, Answer{Type: NumberAnswer, Number: number(10)}, ""},
+		{"number too low", Field{Type: Number, MinValue: 1, MaxValue: 10}, Answer{Type: NumberAnswer, Number: number(0)}, "number"},
+		{"number without bounds", Field{Type: Number}, Answer{Type: NumberAnswer, Number: number(-5)}, ""},
+		{"number below an explicit zero", Field{Type: Number, minValueSet: true}, Answer{Type: NumberAnswer, Number: number(-1)}, "number"},
+		{"number above an explicit zero", Field{Type: Number, maxValueSet: true}, Answer{Type: NumberAnswer, Number: number(1)}, "number"},
+		{"number at an explicit zero", Field{Type: Number, minValueSet: true, maxValueSet: true}, Answer{Type: NumberAnswer, Number: number(0)}, ""},
+		{"missing number", Field{Type: Number}, Answer{Type: NumberAnswer}, "number"},
+		{"rating", Field{Type: Rating}, Answer{Type: NumberAnswer, Number: number(5)}, ""},
+		{"rating out of steps", Field{Type: Rating, Steps: 3}, Answer{Type: NumberAnswer, Number: number(4)}, "number"},
+		{"opinion scale from zero", Field{Type: OpinionScale, Steps: 11}, Answer{Type: NumberAnswer, Number: number(0)}, ""},
+		{"opinion scale from one", Field{Type: OpinionScale, Steps: 5, StartAtOne: true}, Answer{Type: NumberAnswer, Number: number(0)}, "number"},
+		{"missing boolean", Field{Type: YesNo}, Answer{Type: BooleanAnswer}, "boolean"},
+		{"choice", Field{Type: Dropdown, Choices: []Choice{{Label: "Italy"}}}, Answer{Type: ChoiceAnswer, Choice: &SelectedChoice{Label: "Italy"}}, ""},
+		{"unknown choice", Field{Type: Dropdown, Choices: []Choice{{Label: "Italy"}}}, Answer{Type: ChoiceAnswer, Choice: &SelectedChoice{Label: "Spain"}}, "choice.label"},
+		{"other choice", Field{Type: MultipleChoice, Choices: []Choice{{Label: "Red"}}, AddOtherChoice: true}, Answer{Type: ChoiceAnswer, Choice: &SelectedChoice{Other: "Teal"}}, ""},
+		{"no other choice", Field{Type: MultipleChoice, Choices: []Choice{{Label: "Red"}}}, Answer{Type: ChoiceAnswer, Choice: &SelectedChoice{Other: "Teal"}}, "choice.other"},
+		{"choices", Field{Type: MultipleChoice, Choices: []Choice{{Label: "Red"}, {Label: "Blue"}}, AllowMultipleSelections: true}, Answer{Type: ChoicesAnswer, Choices: &SelectedChoices{Labels: []string{"Red", "Blue"}}}, ""},
+		{"unknown choices", Field{Type: MultipleChoice, Choices: []Choice{{Label: "Red"}}, AllowMultipleSelections: true}, Answer{Type: ChoicesAnswer, Choices: &SelectedChoices{Labels: []string{"Red", "Green"}}}, "choices.labels[1]"},
+		{"choices of a single selection", Field{Type: MultipleChoice, Choices: []Choice{{Label: "Red"}}}, Answer{Type: ChoicesAnswer, Choices: &SelectedChoices{Labels: []string{"Red"}}}, "type"},
+		{"statement", Field{Type: Statement}, Answer{Type: TextAnswer, Text: "a"}, "field"},
+	}
+	for _, test := range tests {
+		err := ValidateAnswer(test.field, test.answer)
+		if test.path == "" {
+			assert.Nil(t, err, test.name)
+			continue
+		}
+		assert.Contains(t, violationPaths(err), test.path, test.name)
+	}
+}
+
+func TestValidateSubmission(t *testing.T) {
+	form := Form{
+		Title: "Survey",
+		Fields: []Field{
+			{Type: ShortText, Question: "Name", Required: true},
+			{Type: YesNo, Question: "Customer?", Ref: "customer", Required: true},
+			{Type: Rating, Question: "Rate us", Ref: "rating", Required: true},
+			{Type: Email, Question: "Email", Ref: "email", Required: true},
+		},
+		LogicJumps: []LogicJump{{From: "customer", To: "email", If: false}},
+	}
+	yes, no, five, eleven := true, false, 5, 11
+	name := Answer{Field: AnswerField{ID: "fields[0]"}, Type: TextAnswer, Text: "John"}
+	email := Answer{Field: AnswerField{Ref: "email"}, Type: EmailAnswer, Email: "john@example.com"}
+
+	err := ValidateSubmission(form, []Answer{
+		name,
+		{Field: AnswerField{Ref: "customer"}, Type: BooleanAnswer, Boolean: &no},
+		email,
+	})
+	assert.Nil(t, err, "the rating should not be required when it's skipped")
+
+	err = ValidateSubmission(form, []Answer{
+		name,
+		{Field: AnswerField{Ref: "customer"}, Type: BooleanAnswer, Boolean: &yes},
+		{Field: AnswerField{Ref: "rating"}, Type: NumberAnswer, Number: &five},
+		email,
+	})
+	assert.Nil(t, err, "no error should occur")
+
+	err = ValidateSubmission(form, []Answer{
+		{Field: AnswerField{Ref: "customer"}, Type: BooleanAnswer, Boolean: &no},
+		{Field: AnswerField{Ref: "rating"}, Type: NumberAnswer, Number: &eleven},
+		{Field: AnswerField{Ref: "customer"}, Type: BooleanAnswer, Boolean: &yes},
+		{Field: AnswerField{Ref: "unknown"}, Type: TextAnswer, Text: "?"},
+	})
+	assert.Equal(t, map[string]string{
+		"answers[1].number": "must be between 1 and 5",
+		"answers[1].field":  "fields[2] is skipped by the logic jumps, so it can't be answered",
+		"answers[2].field":  "fields[1] is already answered by answers[0]",
+		"answers[3].field":  `no field of the form has ref "unknown" or id ""`,
+		"fields[0]":         "is required, but has no answer",
+		"fields[3]":         "is required, but has no answer",
+	}, violationPaths(err), "all the violations should be reported")
+}
@@ -0,0 +1,74 @@
+package typeform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateValidForm(t *testing.T) {
+	form := Form{
+		Title: "Valid",
+		Fields: []Field{
+			{Type: ShortText, Question: "Name?", MaxCharacters: 20, Ref: "name"},
+			{Type: MultipleChoice, Question: "Pick", Choices: []Choice{{Label: "a"}, {Label: "b"}}},
+			{Type: PictureChoice, Question: "Pick a picture", Choices: []Choice{{ImageID: "HNdAk47LS"}}},
+			{Type: Number, Question: "How many?", MinValue: 1, MaxValue: 10},
+			{Type: Rating, Question: "Rate", Steps: 5},
+			{Type: OpinionScale, Question: "Scale", Steps: 11},
+			{Type: YesNo, Question: "Yes?", Ref: "yes"},
+		},
+	}
+	assert.Nil(t, form.Validate())
+}
+
+func TestValidateReportsAllViolations(t *testing.T) {
+	form := Form{
+		Fields: []Field{
+			{Type: "unknown", Question: "?"},
+			{Type: Dropdown, Question: "Choose", Ref: "dup"},
+			{Type: PictureChoice, Question: "Pick", Choices: []Choice{{Label: "no image"}}},
+			{Type: Rating, Question: "Rate", Steps: 11},
+			{Type: OpinionScale, Question: "Scale", Steps: 4},
+			{Type: Number, Question: "How many?", MinValue: 10, MaxValue: 1, Ref: "dup"},
+			{Type: Email},
+		},
+	}
+
+	err := form.Validate()
+	assert.NotNil(t, err, "the form is invalid")
+
+	validationErrors, ok := err.(ValidationErrors)
+	assert.True(t, ok, "the error should be ValidationErrors")
+
+	paths := []string{}
+	for _, violation := range validationErrors {
+		paths = append(paths, violation.Path)
+	}
+	assert.Equal(t, []string{
+		"title",
+		"fields[0].type",
+		"fields[1].choices",
+		"fields[2].choices[0].image_id",
+		"fields[3].steps",
+		"fields[4].steps",
+		"fields[5].min_value",
+		"fields[5].ref",
+		"fields[6].question",
+	}, paths)
+}
+
+func TestValidateDefaultSteps(t *testing.T) {
+	form := Form{
+		Title: "Defaults",
+		Fields: []Field{
+			{Type: Rating, Question: "Rate"},
+			{Type: OpinionScale, Question: "Scale"},
+		},
+	}
+	assert.Nil(t, form.Validate(), "fields without steps should use the defaults of the API")
+
+	first, last := stepRange(form.Fields[1])
+	assert.Equal(t, 0, first)
+	assert.Equal(t, 10, last, "opinion scales without steps should have the 11 steps of the API")
+}
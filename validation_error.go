@@ -0,0 +1,130 @@
+package typeform
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormLocation locates a part of a Form
+type FormLocation struct {
+	FieldPath      string // The path as reported, e.g. "fields[2].choices[0].label"
+	FieldIndex     int    // The index of the field in Form.Fields; -1 if the path is not about a field
+	FieldRef       string // The Ref of the field, if any
+	ChoiceIndex    int    // The index of the choice in Field.Choices; -1 if the path is not about a choice
+	LogicJumpIndex int    // The index of the logic jump in Form.LogicJumps; -1 if the path is not about a logic jump
+	Property       string // The remaining part of the path, e.g. "label"
+}
+
+// LocatePath resolves a field path to the part of form it refers to;
+// the segments of the path can be separated by dots or slashes, and indexes can
+// be written as segments or between brackets ("fields.2", "/fields/2", "fields[2]").
+// A field can also be referenced by its Ref instead of its index.
+func LocatePath(form Form, path string) FormLocation {
+	location := FormLocation{
+		FieldPath:      path,
+		FieldIndex:     -1,
+		ChoiceIndex:    -1,
+		LogicJumpIndex: -1,
+	}
+
+	segments := strings.FieldsFunc(path, func(r rune) bool {
+		return r == '.' || r == '/' || r == '[' || r == ']'
+	})
+
+	indexOf := func(segment string, length int) int {
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= length {
+			return -1
+		}
+		return index
+	}
+
+	rest := segments
+	if len(rest) >= 2 && rest[0] == "fields" {
+		location.FieldIndex = indexOf(rest[1], len(form.Fields))
+		if location.FieldIndex == -1 {
+			for i, field := range form.Fields {
+				if field.Ref != "" && field.Ref == rest[1] {
+					location.FieldIndex = i
+					break
+				}
+			}
+		}
+		if location.FieldIndex != -1 {
+			field := form.Fields[location.FieldIndex]
+			location.FieldRef = field.Ref
+			rest = rest[2:]
+			if len(rest) >= 2 && rest[0] == "choices" {
+				location.ChoiceIndex = indexOf(rest[1], len(field.Choices))
+				if location.ChoiceIndex != -1 {
+					rest = rest[2:]
+				}
+			}
+		}
+	} else if len(rest) >= 2 && rest[0] == "logic_jumps" {
+		location.LogicJumpIndex = indexOf(rest[1], len(form.LogicJumps))
+		if location.LogicJumpIndex != -1 {
+			rest = rest[2:]
+		}
+	}
+
+	location.Property = strings.Join(rest, ".")
+	return location
+}
+
+// String returns a human readable description of the location
+func (location FormLocation) String() string {
+	var parts []string
+	if location.FieldIndex != -1 {
+		part := fmt.Sprintf("field %d", location.FieldIndex)
+		if location.FieldRef != "" {
+			part += fmt.Sprintf(" (ref %q)", location.FieldRef)
+		}
+		parts = append(parts, part)
+	}
+	if location.ChoiceIndex != -1 {
+		parts = append(parts, fmt.Sprintf("choice %d", location.ChoiceIndex))
+	}
+	if location.LogicJumpIndex != -1 {
+		parts = append(parts, fmt.Sprintf("logic jump %d", location.LogicJumpIndex))
+	}
+	if location.Property != "" {
+		parts = append(parts, location.Property)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormValidationError is the error returned by CreateForm when the API rejects
+// the submitted form because of one of its fields; it can be
+// unwrapped to the underlying *ResponseError
+type FormValidationError struct {
+	*ResponseError
+	FormLocation
+}
+
+func (validationError *FormValidationError) Error() string {
+	return fmt.Sprintf("%s (%s)", validationError.ResponseError.Error(), validationError.FormLocation.String())
+}
+
+// Unwrap returns the underlying *ResponseError
+func (validationError *FormValidationError) Unwrap() error {
+	return validationError.ResponseError
+}
+
+// newFormValidationError wraps err in a *FormValidationError if it is
+// a validation error naming a field; otherwise err is returned unchanged
+func newFormValidationError(form Form, err error) error {
+	var responseError *ResponseError
+	if !IsValidation(err) || !errors.As(err, &responseError) {
+		return err
+	}
+	if responseError.APIError == nil || responseError.APIError.Field == "" {
+		return err
+	}
+	return &FormValidationError{
+		ResponseError: responseError,
+		FormLocation:  LocatePath(form, responseError.APIError.Field),
+	}
+}
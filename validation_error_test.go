@@ -0,0 +1,65 @@
+package typeform
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocatePath(t *testing.T) {
+	form := Form{
+		Title: "Locate",
+		Fields: []Field{
+			{Type: ShortText, Question: "Name?"},
+			{Type: MultipleChoice, Question: "Pick", Ref: "pick", Choices: []Choice{{Label: "a"}, {Label: "b"}}},
+		},
+		LogicJumps: []LogicJump{{From: "pick", To: "pick", If: true}},
+	}
+
+	for _, path := range []string{"fields[1].choices[1].label", "fields.1.choices.1.label", "/fields/1/choices/1/label", "fields.pick.choices.1.label"} {
+		location := LocatePath(form, path)
+		assert.Equal(t, 1, location.FieldIndex, path)
+		assert.Equal(t, "pick", location.FieldRef, path)
+		assert.Equal(t, 1, location.ChoiceIndex, path)
+		assert.Equal(t, -1, location.LogicJumpIndex, path)
+		assert.Equal(t, "label", location.Property, path)
+	}
+
+	location := LocatePath(form, "logic_jumps[0].to")
+	assert.Equal(t, -1, location.FieldIndex)
+	assert.Equal(t, 0, location.LogicJumpIndex)
+	assert.Equal(t, "to", location.Property)
+
+	location = LocatePath(form, "title")
+	assert.Equal(t, -1, location.FieldIndex)
+	assert.Equal(t, "title", location.Property)
+
+	location = LocatePath(form, "fields[7].question")
+	assert.Equal(t, -1, location.FieldIndex, "out of range indexes should not resolve")
+}
+
+func TestCreateFormValidationError(t *testing.T) {
+	testServer := newErrorServer(http.StatusBadRequest, "application/json", `{"error":"invalid_value","field":"fields[0].max_characters","description":"must be positive"}`)
+	defer testServer.Close()
+
+	errorClient, err := NewClient(Latest, WithBaseURL(testServer.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	assert.Nil(t, err, "no error should occur")
+	errorClient.SetAPIToken("token")
+
+	_, err = errorClient.CreateForm(Form{
+		Title:  "Invalid",
+		Fields: []Field{{Type: ShortText, Question: "Name?", Ref: "name", MaxCharacters: -1}},
+	})
+
+	var validationError *FormValidationError
+	assert.True(t, errors.As(err, &validationError), "the error should locate the field")
+	assert.Equal(t, 0, validationError.FieldIndex)
+	assert.Equal(t, "name", validationError.FieldRef)
+	assert.Equal(t, "max_characters", validationError.Property)
+
+	var responseError *ResponseError
+	assert.True(t, errors.As(err, &responseError), "the error should unwrap to the response error")
+	assert.True(t, IsValidation(err))
+}
@@ -0,0 +1,221 @@
+package typeform
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Submission is the payload posted to the WebhookSubmitURL of a form when a respondent submits it
+type Submission struct {
+	EventID      string       `json:"event_id"`
+	EventType    string       `json:"event_type"` // "form_response"
+	FormResponse FormResponse `json:"form_response"`
+}
+
+// FormResponse is the submitted response carried by a Submission
+type FormResponse struct {
+	FormID      string               `json:"form_id"`
+	Token       string               `json:"token"` // Unique for each submission
+	LandedAt    time.Time            `json:"landed_at"`
+	SubmittedAt time.Time            `json:"submitted_at"`
+	Hidden      map[string]string    `json:"hidden,omitempty"`
+	Definition  SubmissionDefinition `json:"definition"`
+	Answers     []Answer             `json:"answers"`
+}
+
+// SubmissionDefinition describes the form a Submission is for
+type SubmissionDefinition struct {
+	ID     string            `json:"id"`
+	Title  string            `json:"title"`
+	Fields []DefinitionField `json:"fields"`
+}
+
+// DefinitionField describes a field of the form a Submission is for
+type DefinitionField struct {
+	ID    string    `json:"id"`
+	Title string    `json:"title"` // The question of the field
+	Type  FieldType `json:"type"`
+	Ref   string    `json:"ref,omitempty"`
+}
+
+// Response converts the submitted response to a Response, as returned by GetResponses
+func (formResponse FormResponse) Response() Response {
+	return Response{
+		ResponseID:  formResponse.Token,
+		Token:       formResponse.Token,
+		LandedAt:    formResponse.LandedAt,
+		SubmittedAt: formResponse.SubmittedAt,
+		Hidden:      formResponse.Hidden,
+		Answers:     formResponse.Answers,
+	}
+}
+
+// AnswersByRef returns the answers keyed by the Ref of their field
+func (formResponse FormResponse) AnswersByRef() map[string]Answer {
+	return formResponse.Response().AnswersByRef()
+}
+
+// resolveAnswerFields fills the type and ref of the field of each answer from the definition of the form
+func (formResponse *FormResponse) resolveAnswerFields() {
+	fields := map[string]DefinitionField{}
+	for _, field := range formResponse.Definition.Fields {
+		fields[field.ID] = field
+	}
+	for i := range formResponse.Answers {
+		answerField := &formResponse.Answers[i].Field
+		field, ok := fields[answerField.ID]
+		if !ok {
+			continue
+		}
+		if answerField.Type == "" {
+			answerField.Type = field.Type
+		}
+		if answerField.Ref == "" {
+			answerField.Ref = field.Ref
+		}
+	}
+}
+
+// ParseSubmission parses a Submission payload
+func ParseSubmission(body []byte) (*Submission, error) {
+	var submission Submission
+	err := json.Unmarshal(body, &submission)
+	if err != nil {
+		return nil, err
+	}
+	if submission.FormResponse.Token == "" {
+		return nil, errors.New("submission has no token")
+	}
+	submission.FormResponse.resolveAnswerFields()
+	return &submission, nil
+}
+
+// DefaultMaxWebhookBodySize is the default maximum size of a submission payload, after decompression
+const DefaultMaxWebhookBodySize = 5 << 20
+
+// WebhookHandlerFunc handles a submission; returning an error makes
+// the webhook respond with a 500 status, so that the submission is sent again
+type WebhookHandlerFunc func(ctx context.Context, submission *Submission) error
+
+// WebhookOption is used to configure a WebhookHandler in NewWebhookHandler
+type WebhookOption func(handler *WebhookHandler)
+
+// WithMaxBodySize sets the maximum size of a submission payload, after decompression;
+// larger payloads are rejected with a 413 status. A size of 0 or less uses DefaultMaxWebhookBodySize
+func WithMaxBodySize(maxBodySize int64) WebhookOption {
+	return func(handler *WebhookHandler) {
+		if maxBodySize <= 0 {
+			maxBodySize = DefaultMaxWebhookBodySize
+		}
+		handler.maxBodySize = maxBodySize
+	}
+}
+
+// WebhookHandler is an http.Handler receiving the submissions posted to the WebhookSubmitURL of a form
+type WebhookHandler struct {
+	handle      WebhookHandlerFunc
+	maxBodySize int64
+	verifier    *SignatureVerifier
+}
+
+// NewWebhookHandler creates a new WebhookHandler calling handle for each submission
+func NewWebhookHandler(handle WebhookHandlerFunc, options ...WebhookOption) *WebhookHandler {
+	handler := &WebhookHandler{
+		handle:      handle,
+		maxBodySize: DefaultMaxWebhookBodySize,
+	}
+	for _, option := range options {
+		option(handler)
+	}
+	return handler
+}
+
+// errBodyTooLarge is returned by readLimited when the body exceeds the maximum size
+var errBodyTooLarge = errors.New("body too large")
+
+// readLimited reads reader until EOF, failing with errBodyTooLarge after maxBodySize bytes
+func readLimited(reader io.Reader, maxBodySize int64) ([]byte, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(reader, maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxBodySize {
+		return nil, errBodyTooLarge
+	}
+	return body, nil
+}
+
+// decompressWebhookBody decompresses the raw body of a request, up to maxBodySize bytes
+func decompressWebhookBody(request *http.Request, rawBody []byte, maxBodySize int64) ([]byte, error) {
+	if !strings.Contains(request.Header.Get("Content-Encoding"), "gzip") {
+		return rawBody, nil
+	}
+	decompressedBodyReader, err := gzip.NewReader(bytes.NewReader(rawBody))
+	if err != nil {
+		return nil, err
+	}
+	defer decompressedBodyReader.Close()
+	return readLimited(decompressedBodyReader, maxBodySize)
+}
+
+// bodyError responds to a request whose body cannot be read
+func bodyError(w http.ResponseWriter, err error) {
+	if err == errBodyTooLarge {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	http.Error(w, fmt.Sprintf("cannot read body: %s", err), http.StatusBadRequest)
+}
+
+// ServeHTTP parses the submission and calls the handler function
+func (handler *WebhookHandler) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rawBody, err := readLimited(request.Body, handler.maxBodySize)
+	if err != nil {
+		bodyError(w, err)
+		return
+	}
+
+	if handler.verifier != nil {
+		// the signature is computed on the body as sent, before decompression
+		err = handler.verifier.Verify(request.Header, rawBody)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	body, err := decompressWebhookBody(request, rawBody, handler.maxBodySize)
+	if err != nil {
+		bodyError(w, err)
+		return
+	}
+
+	submission, err := ParseSubmission(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid submission: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	err = handler.handle(request.Context(), submission)
+	if err != nil {
+		http.Error(w, "cannot handle submission", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
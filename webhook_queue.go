@@ -0,0 +1,481 @@
+package typeform
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultQueueRetryPolicy is the retry policy used by a SubmissionQueue when none is given
+var DefaultQueueRetryPolicy = RetryPolicy{
+	MaxAttempts: 8,
+	MinBackoff:  time.Second,
+	MaxBackoff:  10 * time.Minute,
+}
+
+// ErrQueueClosed is returned when using a closed SubmissionQueue
+var ErrQueueClosed = errors.New("queue closed")
+
+// QueueOptions configures a SubmissionQueue
+type QueueOptions struct {
+	// RetryPolicy sets how many times a submission is processed before being moved
+	// to the dead letters, and how long to wait between attempts; a zero value uses DefaultQueueRetryPolicy
+	RetryPolicy RetryPolicy
+}
+
+// DeadLetter is a submission whose processing failed on every attempt
+type DeadLetter struct {
+	Submission *Submission `json:"submission"`
+	Attempts   int         `json:"attempts"`
+	LastError  string      `json:"last_error"`
+	FailedAt   time.Time   `json:"failed_at"`
+}
+
+// queueRecord is a line of the write-ahead log of a SubmissionQueue
+type queueRecord struct {
+	Op         string      `json:"op"` // "enqueue", "ack", "fail", "dead", "requeue" or "seen"
+	Token      string      `json:"token"`
+	Submission *Submission `json:"submission,omitempty"`
+	Attempts   int         `json:"attempts,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	At         time.Time   `json:"at"`
+}
+
+// queuedSubmission is a submission waiting to be processed
+type queuedSubmission struct {
+	submission *Submission
+	attempts   int
+	lastError  string
+	notBefore  time.Time
+	processing bool // whether a call to Process is handling the submission
+}
+
+// SubmissionQueue persists the submissions received by a webhook in an append-only file,
+// and processes them asynchronously with at-least-once semantics:
+// a submission is removed from the queue only after it has been processed successfully,
+// so it may be processed again if the process stops in between.
+// Submissions are deduplicated by their token.
+type SubmissionQueue struct {
+	mu          sync.Mutex
+	path        string
+	file        *os.File
+	retryPolicy RetryPolicy
+	seen        map[string]bool // the tokens of all the submissions ever enqueued
+	pending     []*queuedSubmission
+	deadLetters []DeadLetter
+	notify      chan struct{}
+	closed      bool
+	done        chan struct{} // closed with the queue, to stop all the calls to Process
+	now         func() time.Time
+}
+
+// OpenSubmissionQueue opens the queue stored in the file at path, creating it if needed;
+// the submissions left pending by a previous run are processed again
+func OpenSubmissionQueue(path string, options QueueOptions) (*SubmissionQueue, error) {
+	retryPolicy := options.RetryPolicy
+	if retryPolicy.MaxAttempts <= 0 {
+		retryPolicy = DefaultQueueRetryPolicy
+	}
+
+	queue := &SubmissionQueue{
+		path:        path,
+		retryPolicy: retryPolicy,
+		seen:        map[string]bool{},
+		notify:      make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		now:         time.Now,
+	}
+
+	err := queue.replay()
+	if err != nil {
+		return nil, err
+	}
+
+	queue.file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// replay rebuilds the state of the queue from its log
+func (queue *SubmissionQueue) replay() error {
+	file, err := os.Open(queue.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var offset int64 // the end of the last complete record
+	line := 0
+	for {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(data) == 0 {
+				return nil
+			}
+			// a truncated last record is left by a crash while appending; the submission was not acknowledged
+			// to the sender. It's removed, so that the next records are not appended to it.
+			file.Close()
+			return os.Truncate(queue.path, offset)
+		}
+		if err != nil {
+			return err
+		}
+		line++
+
+		var record queueRecord
+		err = json.Unmarshal(data, &record)
+		if err != nil {
+			return fmt.Errorf("%s:%d: corrupted record: %s", queue.path, line, err)
+		}
+		queue.apply(record)
+		offset += int64(len(data))
+	}
+}
+
+// apply updates the state of the queue with a record of its log
+func (queue *SubmissionQueue) apply(record queueRecord) {
+	switch record.Op {
+	case "seen":
+		queue.seen[record.Token] = true
+	case "enqueue":
+		queue.seen[record.Token] = true
+		queue.pending = append(queue.pending, &queuedSubmission{
+			submission: record.Submission,
+			attempts:   record.Attempts,
+			lastError:  record.Error,
+		})
+	case "ack":
+		queue.remove(record.Token)
+	case "fail":
+		if item := queue.find(record.Token); item != nil {
+			item.attempts = record.Attempts
+			item.lastError = record.Error
+			item.notBefore = record.At
+		}
+	case "dead":
+		if item := queue.remove(record.Token); item != nil {
+			queue.deadLetters = append(queue.deadLetters, DeadLetter{
+				Submission: item.submission,
+				Attempts:   record.Attempts,
+				LastError:  record.Error,
+				FailedAt:   record.At,
+			})
+		}
+	case "requeue":
+		for i, deadLetter := range queue.deadLetters {
+			if deadLetter.Submission.FormResponse.Token == record.Token {
+				queue.deadLetters = append(queue.deadLetters[:i], queue.deadLetters[i+1:]...)
+				queue.pending = append(queue.pending, &queuedSubmission{submission: deadLetter.Submission})
+				break
+			}
+		}
+	}
+}
+
+func (queue *SubmissionQueue) find(token string) *queuedSubmission {
+	for _, item := range queue.pending {
+		if item.submission.FormResponse.Token == token {
+			return item
+		}
+	}
+	return nil
+}
+
+func (queue *SubmissionQueue) remove(token string) *queuedSubmission {
+	for i, item := range queue.pending {
+		if item.submission.FormResponse.Token == token {
+			queue.pending = append(queue.pending[:i], queue.pending[i+1:]...)
+			return item
+		}
+	}
+	return nil
+}
+
+// write appends a record to the log and syncs it to disk, then applies it to the state of the queue
+func (queue *SubmissionQueue) write(record queueRecord) error {
+	if queue.closed {
+		return ErrQueueClosed
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	info, err := queue.file.Stat()
+	if err != nil {
+		return err
+	}
+	_, err = queue.file.Write(append(line, '\n'))
+	if err == nil {
+		err = queue.file.Sync()
+	}
+	if err != nil {
+		// a partial record is removed, so that the next records are not appended to it;
+		// if it can't be, the queue is closed, as the log can only be replayed up to it
+		if queue.file.Truncate(info.Size()) != nil {
+			queue.shutdown()
+			queue.file.Close()
+		}
+		return err
+	}
+	queue.apply(record)
+	return nil
+}
+
+// shutdown marks the queue as closed, stopping all the calls to Process
+func (queue *SubmissionQueue) shutdown() {
+	queue.closed = true
+	close(queue.done)
+}
+
+func (queue *SubmissionQueue) wake() {
+	select {
+	case queue.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Enqueue persists a submission; it returns false if a submission with the same token was already enqueued
+func (queue *SubmissionQueue) Enqueue(submission *Submission) (bool, error) {
+	token := submission.FormResponse.Token
+	if token == "" {
+		return false, errors.New("submission has no token")
+	}
+
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	if queue.seen[token] {
+		return false, nil
+	}
+	err := queue.write(queueRecord{Op: "enqueue", Token: token, Submission: submission, At: queue.now()})
+	if err != nil {
+		return false, err
+	}
+	queue.wake()
+	return true, nil
+}
+
+// Handler returns a function enqueueing the submissions, to be used with NewWebhookHandler;
+// the webhook responds only after the submission is persisted
+//
+//	http.Handle("/webhook", typeform.NewWebhookHandler(queue.Handler()))
+func (queue *SubmissionQueue) Handler() WebhookHandlerFunc {
+	return func(ctx context.Context, submission *Submission) error {
+		_, err := queue.Enqueue(submission)
+		return err
+	}
+}
+
+// next returns the first submission ready to be processed and not being processed, or how long to wait for one
+func (queue *SubmissionQueue) next() (*queuedSubmission, time.Duration) {
+	now := queue.now()
+	var wait time.Duration = -1
+	for _, item := range queue.pending {
+		if item.processing {
+			continue
+		}
+		if !item.notBefore.After(now) {
+			return item, 0
+		}
+		if until := item.notBefore.Sub(now); wait < 0 || until < wait {
+			wait = until
+		}
+	}
+	return nil, wait
+}
+
+// Process calls handle for each queued submission, one at a time, until ctx is done;
+// a submission for which handle returns an error is retried according to the retry policy of the queue,
+// and is moved to the dead letters after the last attempt.
+// Process can be called concurrently: each submission is handled by one call at a time.
+func (queue *SubmissionQueue) Process(ctx context.Context, handle WebhookHandlerFunc) error {
+	for {
+		queue.mu.Lock()
+		if queue.closed {
+			queue.mu.Unlock()
+			return ErrQueueClosed
+		}
+		item, wait := queue.next()
+		if item != nil {
+			item.processing = true
+		}
+		queue.mu.Unlock()
+
+		if item == nil {
+			var timer <-chan time.Time
+			if wait >= 0 {
+				timer = time.After(wait)
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-queue.done:
+				return ErrQueueClosed
+			case <-queue.notify:
+			case <-timer:
+			}
+			continue
+		}
+
+		err := queue.processOne(ctx, handle, item)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// processOne calls handle for a submission, and records the outcome
+func (queue *SubmissionQueue) processOne(ctx context.Context, handle WebhookHandlerFunc, item *queuedSubmission) error {
+	handleErr := handle(ctx, item.submission)
+
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	item.processing = false
+	if handleErr != nil && ctx.Err() != nil {
+		// the processing was interrupted, not failed: another call to Process can take the submission
+		queue.wake()
+		return ctx.Err()
+	}
+
+	token := item.submission.FormResponse.Token
+	if handleErr == nil {
+		return queue.write(queueRecord{Op: "ack", Token: token, At: queue.now()})
+	}
+
+	attempts := item.attempts + 1
+	if attempts >= queue.retryPolicy.MaxAttempts {
+		return queue.write(queueRecord{Op: "dead", Token: token, Attempts: attempts, Error: handleErr.Error(), At: queue.now()})
+	}
+	notBefore := queue.now().Add(queue.retryPolicy.backoff(attempts, http.Header{}))
+	return queue.write(queueRecord{Op: "fail", Token: token, Attempts: attempts, Error: handleErr.Error(), At: notBefore})
+}
+
+// Len returns the number of submissions waiting to be processed
+func (queue *SubmissionQueue) Len() int {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return len(queue.pending)
+}
+
+// DeadLetters returns the submissions whose processing failed on every attempt
+func (queue *SubmissionQueue) DeadLetters() []DeadLetter {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return append([]DeadLetter(nil), queue.deadLetters...)
+}
+
+// Requeue moves the dead letter of the submission with the given token back to the queue
+func (queue *SubmissionQueue) Requeue(token string) error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	for _, deadLetter := range queue.deadLetters {
+		if deadLetter.Submission.FormResponse.Token == token {
+			err := queue.write(queueRecord{Op: "requeue", Token: token, At: queue.now()})
+			if err != nil {
+				return err
+			}
+			queue.wake()
+			return nil
+		}
+	}
+	return fmt.Errorf("no dead letter with token %q", token)
+}
+
+// Compact rewrites the log of the queue keeping only its current state,
+// i.e. the pending submissions, the dead letters, and the tokens of the processed submissions
+func (queue *SubmissionQueue) Compact() error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	if queue.closed {
+		return ErrQueueClosed
+	}
+
+	compactedPath := queue.path + ".compact"
+	compacted, err := os.OpenFile(compactedPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	records := []queueRecord{}
+	live := map[string]bool{}
+	for _, item := range queue.pending {
+		token := item.submission.FormResponse.Token
+		live[token] = true
+		records = append(records, queueRecord{Op: "enqueue", Token: token, Submission: item.submission, Attempts: item.attempts, Error: item.lastError})
+		if !item.notBefore.IsZero() {
+			records = append(records, queueRecord{Op: "fail", Token: token, Attempts: item.attempts, Error: item.lastError, At: item.notBefore})
+		}
+	}
+	for _, deadLetter := range queue.deadLetters {
+		token := deadLetter.Submission.FormResponse.Token
+		live[token] = true
+		records = append(records,
+			queueRecord{Op: "enqueue", Token: token, Submission: deadLetter.Submission},
+			queueRecord{Op: "dead", Token: token, Attempts: deadLetter.Attempts, Error: deadLetter.LastError, At: deadLetter.FailedAt},
+		)
+	}
+	for token := range queue.seen {
+		if !live[token] {
+			records = append(records, queueRecord{Op: "seen", Token: token})
+		}
+	}
+
+	writer := bufio.NewWriter(compacted)
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		err = encoder.Encode(record)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = compacted.Sync()
+	}
+	compacted.Close()
+	if err != nil {
+		os.Remove(compactedPath)
+		return err
+	}
+
+	err = os.Rename(compactedPath, queue.path)
+	if err != nil {
+		return err
+	}
+	queue.file.Close()
+	queue.file, err = os.OpenFile(queue.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		queue.shutdown()
+	}
+	return err
+}
+
+// Close closes the log of the queue; Process returns ErrQueueClosed
+func (queue *SubmissionQueue) Close() error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	if queue.closed {
+		return nil
+	}
+	queue.shutdown()
+	return queue.file.Close()
+}
@@ -0,0 +1,245 @@
+package typeform
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSubmission(token string) *Submission {
+	return &Submission{EventID: "e-" + token, EventType: "form_response", FormResponse: FormResponse{FormID: "abc", Token: token}}
+}
+
+var testQueueOptions = QueueOptions{RetryPolicy: RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+
+// processUntil processes the queue until it is empty
+func processUntil(t *testing.T, queue *SubmissionQueue, handle WebhookHandlerFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- queue.Process(ctx, handle)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestSubmissionQueueDedupe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	queue, err := OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+
+	handler := NewWebhookHandler(queue.Handler())
+	for i := 0; i < 2; i++ {
+		request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody))
+		assert.Equal(t, http.StatusOK, serveWebhook(handler, request).Code)
+	}
+	assert.Equal(t, 1, queue.Len(), "the same submission should be queued once")
+	assert.Nil(t, queue.Close())
+
+	// the pending submission survives a restart, and is still deduplicated
+	queue, err = OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+	defer queue.Close()
+	assert.Equal(t, 1, queue.Len())
+
+	processed := []string{}
+	processUntil(t, queue, func(ctx context.Context, submission *Submission) error {
+		processed = append(processed, submission.FormResponse.Token)
+		return nil
+	})
+	assert.Equal(t, []string{"t1"}, processed)
+
+	added, err := queue.Enqueue(newTestSubmission("t1"))
+	assert.Nil(t, err, "no error should occur")
+	assert.False(t, added, "a processed submission should not be queued again")
+}
+
+func TestSubmissionQueueRetries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	queue, err := OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+
+	queue.Enqueue(newTestSubmission("flaky"))
+	queue.Enqueue(newTestSubmission("broken"))
+
+	attempts := map[string]int{}
+	processUntil(t, queue, func(ctx context.Context, submission *Submission) error {
+		token := submission.FormResponse.Token
+		attempts[token]++
+		if token == "broken" || attempts[token] < 2 {
+			return errors.New("consumer down")
+		}
+		return nil
+	})
+
+	assert.Equal(t, map[string]int{"flaky": 2, "broken": 3}, attempts)
+	deadLetters := queue.DeadLetters()
+	assert.Len(t, deadLetters, 1)
+	assert.Equal(t, "broken", deadLetters[0].Submission.FormResponse.Token)
+	assert.Equal(t, 3, deadLetters[0].Attempts)
+	assert.Equal(t, "consumer down", deadLetters[0].LastError)
+
+	// the state survives compaction and a restart
+	assert.Nil(t, queue.Compact())
+	assert.Nil(t, queue.Close())
+	queue, err = OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+	defer queue.Close()
+
+	assert.Len(t, queue.DeadLetters(), 1)
+	added, _ := queue.Enqueue(newTestSubmission("flaky"))
+	assert.False(t, added, "processed tokens should be kept by compaction")
+
+	assert.Nil(t, queue.Requeue("broken"))
+	assert.Equal(t, 1, queue.Len())
+	assert.Len(t, queue.DeadLetters(), 0)
+	processUntil(t, queue, func(ctx context.Context, submission *Submission) error {
+		return nil
+	})
+	assert.Equal(t, 0, queue.Len())
+}
+
+func TestSubmissionQueueTruncatedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	queue, err := OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+	queue.Enqueue(newTestSubmission("t1"))
+	assert.Nil(t, queue.Close())
+
+	// a crash while appending leaves a partial record
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	assert.Nil(t, err, "no error should occur")
+	file.WriteString(`{"op":"enqueue","token":"t2","submi`)
+	file.Close()
+
+	queue, err = OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "the partial record should be dropped")
+	assert.Equal(t, 1, queue.Len())
+	added, err := queue.Enqueue(newTestSubmission("t2"))
+	assert.Nil(t, err, "no error should occur")
+	assert.True(t, added)
+	assert.Nil(t, queue.Close())
+
+	queue, err = OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "the records appended after the partial one should be readable")
+	defer queue.Close()
+	assert.Equal(t, 2, queue.Len())
+}
+
+func TestSubmissionQueueLargeRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	queue, err := OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+	submission := newTestSubmission("large")
+	submission.FormResponse.Hidden = map[string]string{"payload": strings.Repeat("x", 3*DefaultMaxWebhookBodySize)}
+	queue.Enqueue(submission)
+	assert.Nil(t, queue.Close())
+
+	queue, err = OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "records larger than the default body size should be replayed")
+	defer queue.Close()
+	assert.Equal(t, 1, queue.Len())
+}
+
+func TestSubmissionQueueConcurrentProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	queue, err := OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+	defer queue.Close()
+	for i := 0; i < 20; i++ {
+		queue.Enqueue(newTestSubmission(strconv.Itoa(i)))
+	}
+
+	var mu sync.Mutex
+	handled := map[string]int{}
+	handle := func(ctx context.Context, submission *Submission) error {
+		mu.Lock()
+		handled[submission.FormResponse.Token]++
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	for i := 0; i < 4; i++ {
+		go func() {
+			done <- queue.Process(ctx, handle)
+		}()
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, context.Canceled, <-done)
+	}
+
+	assert.Len(t, handled, 20)
+	for token, count := range handled {
+		assert.Equal(t, 1, count, "submission %s should be handled once", token)
+	}
+}
+
+func TestSubmissionQueueCloseStopsProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	queue, err := OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+
+	done := make(chan error)
+	for i := 0; i < 3; i++ {
+		go func() {
+			done <- queue.Process(context.Background(), func(ctx context.Context, submission *Submission) error {
+				return nil
+			})
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, queue.Close())
+
+	for i := 0; i < 3; i++ {
+		select {
+		case err := <-done:
+			assert.Equal(t, ErrQueueClosed, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("every call to Process should return when the queue is closed")
+		}
+	}
+}
+
+func TestSubmissionQueueWriteError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.log")
+	queue, err := OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "no error should occur")
+	queue.Enqueue(newTestSubmission("t1"))
+
+	// a log that can't be written to, nor truncated, closes the queue
+	queue.file.Close()
+	queue.file, err = os.Open(path)
+	assert.Nil(t, err, "no error should occur")
+	_, err = queue.Enqueue(newTestSubmission("t2"))
+	assert.NotNil(t, err, "the write should fail")
+	_, err = queue.Enqueue(newTestSubmission("t3"))
+	assert.Equal(t, ErrQueueClosed, err)
+	assert.Nil(t, queue.Close())
+
+	queue, err = OpenSubmissionQueue(path, testQueueOptions)
+	assert.Nil(t, err, "the log should still be readable")
+	defer queue.Close()
+	assert.Equal(t, 1, queue.Len())
+}
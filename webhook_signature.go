@@ -0,0 +1,174 @@
+package typeform
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSignatureHeader is the header carrying the signature of a submission
+const DefaultSignatureHeader = "Typeform-Signature"
+
+// DefaultSignatureTolerance is the maximum age of a timestamped submission
+const DefaultSignatureTolerance = 5 * time.Minute
+
+const signaturePrefix = "sha256="
+
+var (
+	// ErrMissingSignature is returned when a submission is not signed
+	ErrMissingSignature = errors.New("missing signature")
+	// ErrInvalidSignature is returned when the signature of a submission matches none of the secrets
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrSignatureExpired is returned when the timestamp of a submission is outside of the tolerance
+	ErrSignatureExpired = errors.New("signature timestamp outside of tolerance")
+	// ErrNoSecrets is returned when a signature is verified without any non-empty secret
+	ErrNoSecrets = errors.New("no secret to verify the signature with")
+)
+
+// ComputeSignature returns the signature of payload with secret, in the "sha256=<base64>" format of the signature header
+func ComputeSignature(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return signaturePrefix + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks that header is the signature of body with one of the secrets;
+// several secrets can be active while a secret is being rotated. Empty secrets are ignored,
+// and ErrNoSecrets is returned if no other secret is given.
+func VerifySignature(body []byte, header string, secrets []string) error {
+	hasSecret := false
+	for _, secret := range secrets {
+		if secret != "" {
+			hasSecret = true
+			break
+		}
+	}
+	if !hasSecret {
+		return ErrNoSecrets
+	}
+
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return ErrMissingSignature
+	}
+	if !strings.HasPrefix(header, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	for _, secret := range secrets {
+		if secret != "" && hmac.Equal([]byte(header), []byte(ComputeSignature(body, secret))) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// SignatureVerifier verifies the signature of the submissions posted to a webhook
+type SignatureVerifier struct {
+	Secrets []string // The active secrets; a signature matching any of them is valid
+	Header  string   // The header carrying the signature; "" uses DefaultSignatureHeader
+
+	// TimestampHeader is the header carrying the unix time at which the submission was signed;
+	// if set, the signed payload is "<timestamp>.<body>", and older submissions are rejected to prevent replays
+	TimestampHeader string
+	Tolerance       time.Duration // The maximum age of a submission; 0 uses DefaultSignatureTolerance
+
+	// MaxBodySize is the maximum size of the bodies read by Middleware; 0 uses DefaultMaxWebhookBodySize.
+	// It's not used by WithSignatureVerifier, which reads the body up to the size set by WithMaxBodySize
+	MaxBodySize int64
+
+	now func() time.Time
+}
+
+// NewSignatureVerifier creates a new SignatureVerifier accepting the signatures made with any of secrets
+func NewSignatureVerifier(secrets ...string) (*SignatureVerifier, error) {
+	if len(secrets) == 0 {
+		return nil, errors.New("at least one secret is needed")
+	}
+	for _, secret := range secrets {
+		if secret == "" {
+			return nil, errors.New("secret cannot be empty")
+		}
+	}
+	return &SignatureVerifier{
+		Secrets: secrets,
+		Header:  DefaultSignatureHeader,
+		now:     time.Now,
+	}, nil
+}
+
+// Verify checks the signature of a submission, given its headers and its raw body
+func (verifier *SignatureVerifier) Verify(header http.Header, body []byte) error {
+	headerName := verifier.Header
+	if headerName == "" {
+		headerName = DefaultSignatureHeader
+	}
+	if verifier.TimestampHeader == "" {
+		return VerifySignature(body, header.Get(headerName), verifier.Secrets)
+	}
+
+	timestamp := strings.TrimSpace(header.Get(verifier.TimestampHeader))
+	if timestamp == "" {
+		return ErrMissingSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp %q", timestamp)
+	}
+
+	tolerance := verifier.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultSignatureTolerance
+	}
+	now := time.Now
+	if verifier.now != nil {
+		now = verifier.now
+	}
+	age := now().Sub(time.Unix(seconds, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	payload := append([]byte(timestamp+"."), body...)
+	return VerifySignature(payload, header.Get(headerName), verifier.Secrets)
+}
+
+// Middleware returns a handler rejecting with a 401 status the requests that are not correctly signed,
+// and with a 413 status the bodies larger than MaxBodySize; the body of the accepted requests is passed unchanged to next
+func (verifier *SignatureVerifier) Middleware(next http.Handler) http.Handler {
+	maxBodySize := verifier.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxWebhookBodySize
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		body, err := readLimited(request.Body, maxBodySize)
+		if err != nil {
+			bodyError(w, err)
+			return
+		}
+
+		err = verifier.Verify(request.Header, body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, request)
+	})
+}
+
+// WithSignatureVerifier makes a WebhookHandler reject with a 401 status the submissions that are not correctly signed
+func WithSignatureVerifier(verifier *SignatureVerifier) WebhookOption {
+	return func(handler *WebhookHandler) {
+		handler.verifier = verifier
+	}
+}
@@ -0,0 +1,125 @@
+package typeform
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(testSubmissionBody)
+	signature := ComputeSignature(body, "new-secret")
+
+	assert.Nil(t, VerifySignature(body, signature, []string{"old-secret", "new-secret"}), "any active secret should be accepted")
+	assert.Equal(t, ErrInvalidSignature, VerifySignature(body, signature, []string{"old-secret"}))
+	assert.Equal(t, ErrInvalidSignature, VerifySignature(append(body, ' '), signature, []string{"new-secret"}))
+	assert.Equal(t, ErrInvalidSignature, VerifySignature(body, strings.TrimPrefix(signature, "sha256="), []string{"new-secret"}))
+	assert.Equal(t, ErrMissingSignature, VerifySignature(body, "", []string{"new-secret"}))
+
+	// an empty secret must not accept the signatures made with it
+	emptySignature := ComputeSignature(body, "")
+	assert.Equal(t, ErrInvalidSignature, VerifySignature(body, emptySignature, []string{"", "new-secret"}))
+	assert.Equal(t, ErrNoSecrets, VerifySignature(body, emptySignature, []string{""}))
+	assert.Equal(t, ErrNoSecrets, VerifySignature(body, signature, nil))
+	assert.Equal(t, ErrNoSecrets, (&SignatureVerifier{Secrets: []string{""}}).Verify(http.Header{DefaultSignatureHeader: {emptySignature}}, body))
+}
+
+func TestSignatureVerifierTimestamp(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	verifier, err := NewSignatureVerifier("secret")
+	assert.Nil(t, err, "no error should occur")
+	verifier.Header = "X-Signature"
+	verifier.TimestampHeader = "X-Timestamp"
+	verifier.now = func() time.Time { return now }
+
+	body := []byte(testSubmissionBody)
+	signed := func(at time.Time) http.Header {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		header := http.Header{}
+		header.Set("X-Timestamp", timestamp)
+		header.Set("X-Signature", ComputeSignature([]byte(timestamp+"."+string(body)), "secret"))
+		return header
+	}
+
+	assert.Nil(t, verifier.Verify(signed(now.Add(-time.Minute)), body))
+	assert.Equal(t, ErrSignatureExpired, verifier.Verify(signed(now.Add(-time.Hour)), body), "replayed submissions should be rejected")
+
+	header := signed(now)
+	header.Set("X-Timestamp", strconv.FormatInt(now.Unix()+1, 10))
+	assert.Equal(t, ErrInvalidSignature, verifier.Verify(header, body), "the timestamp should be signed")
+}
+
+func TestWebhookHandlerSignature(t *testing.T) {
+	verifier, err := NewSignatureVerifier("secret")
+	assert.Nil(t, err, "no error should occur")
+
+	handled := 0
+	handler := NewWebhookHandler(func(ctx context.Context, submission *Submission) error {
+		handled++
+		return nil
+	}, WithSignatureVerifier(verifier))
+
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody))
+	request.Header.Set(DefaultSignatureHeader, ComputeSignature([]byte(testSubmissionBody), "secret"))
+	assert.Equal(t, http.StatusOK, serveWebhook(handler, request).Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody))
+	request.Header.Set(DefaultSignatureHeader, ComputeSignature([]byte(testSubmissionBody), "other"))
+	assert.Equal(t, http.StatusUnauthorized, serveWebhook(handler, request).Code)
+
+	// as middleware
+	middleware := verifier.Middleware(NewWebhookHandler(func(ctx context.Context, submission *Submission) error {
+		handled++
+		return nil
+	}))
+	request = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody))
+	request.Header.Set(DefaultSignatureHeader, ComputeSignature([]byte(testSubmissionBody), "secret"))
+	assert.Equal(t, http.StatusOK, serveWebhook(middleware, request).Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody))
+	assert.Equal(t, http.StatusUnauthorized, serveWebhook(middleware, request).Code)
+
+	assert.Equal(t, 2, handled)
+}
+
+func TestSignatureMiddlewareBodyErrors(t *testing.T) {
+	verifier, err := NewSignatureVerifier("secret")
+	assert.Nil(t, err, "no error should occur")
+	middleware := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		t.Error("the request should be rejected")
+	}))
+
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(strings.Repeat(" ", DefaultMaxWebhookBodySize+1)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, serveWebhook(middleware, request).Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/webhook", io.MultiReader(strings.NewReader("{"), failingReader{}))
+	assert.Equal(t, http.StatusBadRequest, serveWebhook(middleware, request).Code)
+
+	// a larger limit accepts the bodies allowed by WithMaxBodySize
+	body := `{"padding":"` + strings.Repeat(" ", DefaultMaxWebhookBodySize) + `",` + testSubmissionBody[1:]
+	verifier.MaxBodySize = 2 * DefaultMaxWebhookBodySize
+	handled := false
+	middleware = verifier.Middleware(NewWebhookHandler(func(ctx context.Context, submission *Submission) error {
+		handled = true
+		return nil
+	}, WithMaxBodySize(2*DefaultMaxWebhookBodySize)))
+	request = httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	request.Header.Set(DefaultSignatureHeader, ComputeSignature([]byte(body), "secret"))
+	assert.Equal(t, http.StatusOK, serveWebhook(middleware, request).Code)
+	assert.True(t, handled)
+}
+
+// failingReader is a reader that always fails
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
@@ -0,0 +1,104 @@
+package typeform
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSubmissionBody = `{
+	"event_id": "e1",
+	"event_type": "form_response",
+	"form_response": {
+		"form_id": "abc",
+		"token": "t1",
+		"landed_at": "2017-02-28T10:00:00Z",
+		"submitted_at": "2017-02-28T10:05:00Z",
+		"hidden": {"source": "newsletter"},
+		"definition": {
+			"id": "abc",
+			"title": "Survey",
+			"fields": [
+				{"id": "f1", "title": "Name?", "type": "short_text", "ref": "name"},
+				{"id": "f2", "title": "Jump?", "type": "yes_no", "ref": "jump"}
+			]
+		},
+		"answers": [
+			{"field": {"id": "f1"}, "type": "text", "text": "Ada"},
+			{"field": {"id": "f2", "type": "yes_no"}, "type": "boolean", "boolean": true}
+		]
+	}
+}`
+
+func serveWebhook(handler http.Handler, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var received *Submission
+	handler := NewWebhookHandler(func(ctx context.Context, submission *Submission) error {
+		received = submission
+		return nil
+	})
+
+	recorder := serveWebhook(handler, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.Equal(t, "t1", received.FormResponse.Token)
+	answers := received.FormResponse.AnswersByRef()
+	assert.Equal(t, "Ada", answers["name"].Value(), "the answer field should be resolved from the definition")
+	assert.Equal(t, true, answers["jump"].Value())
+}
+
+func TestWebhookHandlerGzip(t *testing.T) {
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	gzipWriter.Write([]byte(testSubmissionBody))
+	gzipWriter.Close()
+
+	handled := false
+	handler := NewWebhookHandler(func(ctx context.Context, submission *Submission) error {
+		handled = true
+		return nil
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/webhook", &compressed)
+	request.Header.Set("Content-Encoding", "gzip")
+	recorder := serveWebhook(handler, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, handled)
+}
+
+func TestWebhookHandlerStatusCodes(t *testing.T) {
+	handler := NewWebhookHandler(func(ctx context.Context, submission *Submission) error {
+		return errors.New("database down")
+	}, WithMaxBodySize(int64(len(testSubmissionBody))))
+
+	recorder := serveWebhook(handler, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = serveWebhook(handler, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody+" ")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+
+	recorder = serveWebhook(handler, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"form_response":`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = serveWebhook(handler, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody)))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	// a size of 0 uses the default, instead of rejecting every payload
+	handler = NewWebhookHandler(func(ctx context.Context, submission *Submission) error {
+		return nil
+	}, WithMaxBodySize(0))
+	recorder = serveWebhook(handler, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testSubmissionBody)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}